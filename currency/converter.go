@@ -0,0 +1,179 @@
+package currency
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redforks/math/decimal"
+)
+
+// minorUnits are ISO 4217 minor units of currencies not using 2 digits.
+var minorUnits = map[string]int{
+	"BHD": 3, "BIF": 0, "CLF": 4, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3,
+	"ISK": 0, "JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3,
+	"OMR": 3, "PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "UYI": 0, "UYW": 4,
+	"VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// MinorUnit returns ISO 4217 minor unit of currency, the number of digits
+// after the decimal point, such as 2 for USD, 0 for JPY. Returns 2 for unknown
+// currencies.
+func MinorUnit(code string) int {
+	if n, ok := minorUnits[code]; ok {
+		return n
+	}
+	return 2
+}
+
+// Leg is an exchange rate applied in a conversion.
+type Leg struct {
+	From, To string
+
+	// Rate quoted in the table, 1 unit of From equals to Rate units of To if
+	// not Inverse.
+	Rate decimal.Decimal
+
+	// Inverse is true if the table has only To/From rate, amount divided by
+	// Rate.
+	Inverse bool
+}
+
+func (l Leg) String() string {
+	if l.Inverse {
+		return fmt.Sprintf("%s/%s 1/%s", l.From, l.To, l.Rate)
+	}
+	return fmt.Sprintf("%s/%s %s", l.From, l.To, l.Rate)
+}
+
+// Conversion records a conversion for audit.
+type Conversion struct {
+	Amount decimal.Decimal
+	From   string
+	Result decimal.Decimal
+	To     string
+
+	// Legs are rates applied in order, empty if From equals To, two legs if
+	// triangulated through base currency.
+	Legs []Leg
+
+	// Mode rounds the exact result to the scale of Result, rounded only once
+	// after all legs applied.
+	Mode decimal.RoundingMode
+}
+
+// String returns conversion as text, such as
+// "100.00 USD = 712.34 CNY (USD/CNY 7.1234, HalfUp)".
+func (c Conversion) String() string {
+	legs := make([]string, len(c.Legs))
+	for i, l := range c.Legs {
+		legs[i] = l.String()
+	}
+	return fmt.Sprintf("%s %s = %s %s (%s, %s)", c.Amount, c.From, c.Result, c.To, strings.Join(legs, ", "), c.Mode)
+}
+
+// Converter converts amounts between currencies.
+type Converter struct {
+	// Rates is the exchange-rate table, required.
+	Rates *Table
+
+	// Base currency to triangulate if neither direct nor inverse rate exists,
+	// no triangulation if empty.
+	Base string
+
+	// Scales overrides scale of currencies, such as 4 for USD to compute
+	// in 1/100 cents. Scale of currency not in Scales is its MinorUnit.
+	Scales map[string]int
+
+	// Mode of rounding results.
+	Mode decimal.RoundingMode
+
+	// Audit is called on each successful conversion if not nil.
+	Audit func(Conversion)
+}
+
+// Convert converts amount from one currency to another, result round to the
+// scale of target currency. Looks up direct rate from/to first, then inverse
+// rate to/from, then triangulates through base currency. Returns error if
+// Rates is nil, no rate found or result out of range.
+func (c *Converter) Convert(amount decimal.Decimal, from, to string) (Conversion, error) {
+	if c.Rates == nil {
+		return Conversion{}, fmt.Errorf("[%s] no exchange rate table", tag)
+	}
+
+	scale := c.scale(to)
+	if scale < 0 || scale > decimal.MaxScale {
+		return Conversion{}, fmt.Errorf("[%s] scale %d of %s out of range", tag, scale, to)
+	}
+
+	legs, ok := c.legs(from, to)
+	if !ok {
+		return Conversion{}, fmt.Errorf("[%s] no exchange rate from %s to %s", tag, from, to)
+	}
+
+	r := amount.ToBigRat()
+	for _, l := range legs {
+		if l.Inverse {
+			r.Quo(r, l.Rate.ToBigRat())
+		} else {
+			r.Mul(r, l.Rate.ToBigRat())
+		}
+	}
+	result, err := decimal.FromBigRat(r, scale, c.Mode)
+	if err != nil {
+		return Conversion{}, err
+	}
+
+	conv := Conversion{
+		Amount: amount,
+		From:   from,
+		Result: result,
+		To:     to,
+		Legs:   legs,
+		Mode:   c.Mode,
+	}
+	if c.Audit != nil {
+		c.Audit(conv)
+	}
+	return conv, nil
+}
+
+func (c *Converter) scale(code string) int {
+	if n, ok := c.Scales[code]; ok {
+		return n
+	}
+	return MinorUnit(code)
+}
+
+// legs returns rates converts from one currency to another.
+func (c *Converter) legs(from, to string) ([]Leg, bool) {
+	if from == to {
+		return nil, true
+	}
+	if l, ok := c.leg(from, to); ok {
+		return []Leg{l}, true
+	}
+
+	if c.Base == "" || c.Base == from || c.Base == to {
+		return nil, false
+	}
+	first, ok := c.leg(from, c.Base)
+	if !ok {
+		return nil, false
+	}
+	second, ok := c.leg(c.Base, to)
+	if !ok {
+		return nil, false
+	}
+	return []Leg{first, second}, true
+}
+
+// leg returns direct or inverse rate from one currency to another.
+func (c *Converter) leg(from, to string) (Leg, bool) {
+	if rate, ok := c.Rates.Get(from, to); ok {
+		return Leg{from, to, rate, false}, true
+	}
+	if rate, ok := c.Rates.Get(to, from); ok {
+		return Leg{from, to, rate, true}, true
+	}
+	return Leg{}, false
+}
@@ -0,0 +1,155 @@
+package currency_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	. "github.com/redforks/math/currency"
+	"github.com/redforks/math/decimal"
+)
+
+var _ = Describe("Converter", func() {
+	var (
+		c      *Converter
+		audits []Conversion
+	)
+
+	BeforeEach(func() {
+		rates, err := LoadCSV(strings.NewReader(`from,to,rate
+USD,CNY,7.1234
+EUR,USD,1.0856
+USD,JPY,149.87654321
+USD,KWD,0.3075
+`))
+		Ω(err).Should(Succeed())
+
+		audits = nil
+		c = &Converter{Rates: rates, Base: "USD", Audit: func(conv Conversion) {
+			audits = append(audits, conv)
+		}}
+	})
+
+	DescribeTable("Convert", func(amount, from, to, exp string) {
+		conv, err := c.Convert(toDecimal(amount), from, to)
+		Ω(err).Should(Succeed())
+		Ω(conv.Result).Should(Equal(toDecimal(exp)))
+	},
+		Entry("direct", "100", "USD", "CNY", "712.34"),
+		Entry("inverse", "712.34", "CNY", "USD", "100.00"),
+		Entry("zero minor unit", "10.50", "USD", "JPY", "1574"),
+		Entry("three minor units", "10", "USD", "KWD", "3.075"),
+		Entry("triangulate", "100", "EUR", "CNY", "773.32"),
+		Entry("triangulate inverse", "773.32", "CNY", "EUR", "100.00"),
+		Entry("triangulate to zero minor unit", "1", "EUR", "JPY", "163"),
+		Entry("same currency", "1.005", "USD", "USD", "1.01"),
+		Entry("negative", "-100", "USD", "CNY", "-712.34"),
+	)
+
+	It("rounding mode and scales", func() {
+		c.Mode = decimal.Down
+		c.Scales = map[string]int{"CNY": 4}
+		conv, err := c.Convert(toDecimal("0.015"), "USD", "CNY")
+		Ω(err).Should(Succeed())
+		Ω(conv.Result).Should(Equal(toDecimal("0.1068")))
+
+		conv, err = c.Convert(toDecimal("0.015"), "USD", "EUR")
+		Ω(err).Should(Succeed())
+		Ω(conv.Result).Should(Equal(toDecimal("0.01")))
+	})
+
+	It("audit", func() {
+		conv, err := c.Convert(toDecimal("100.00"), "EUR", "CNY")
+		Ω(err).Should(Succeed())
+		Ω(audits).Should(Equal([]Conversion{conv}))
+		Ω(conv.Legs).Should(Equal([]Leg{
+			{"EUR", "USD", toDecimal("1.0856"), false},
+			{"USD", "CNY", toDecimal("7.1234"), false},
+		}))
+		Ω(conv.String()).Should(Equal("100.00 EUR = 773.32 CNY (EUR/USD 1.0856, USD/CNY 7.1234, HalfUp)"))
+
+		conv, err = c.Convert(toDecimal("712.34"), "CNY", "USD")
+		Ω(err).Should(Succeed())
+		Ω(conv.String()).Should(Equal("712.34 CNY = 100.00 USD (CNY/USD 1/7.1234, HalfUp)"))
+		Ω(audits).Should(HaveLen(2))
+	})
+
+	It("no rate", func() {
+		_, err := c.Convert(toDecimal("1"), "USD", "GBP")
+		Ω(err).Should(MatchError("[math-currency] no exchange rate from USD to GBP"))
+
+		c.Base = ""
+		_, err = c.Convert(toDecimal("1"), "EUR", "CNY")
+		Ω(err).Should(MatchError("[math-currency] no exchange rate from EUR to CNY"))
+		Ω(audits).Should(BeEmpty())
+	})
+
+	It("no rate table", func() {
+		_, err := (&Converter{}).Convert(toDecimal("1"), "USD", "CNY")
+		Ω(err).Should(MatchError("[math-currency] no exchange rate table"))
+	})
+
+	It("scale out of range", func() {
+		c.Scales = map[string]int{"CNY": 19}
+		_, err := c.Convert(toDecimal("1"), "USD", "CNY")
+		Ω(err).Should(MatchError("[math-currency] scale 19 of CNY out of range"))
+	})
+
+	It("overflow", func() {
+		_, err := c.Convert(decimal.FromInt(1<<62), "USD", "JPY")
+		Ω(err).Should(Equal(decimal.ErrOverflow))
+	})
+
+	It("MinorUnit", func() {
+		Ω(MinorUnit("USD")).Should(Equal(2))
+		Ω(MinorUnit("JPY")).Should(Equal(0))
+		Ω(MinorUnit("BHD")).Should(Equal(3))
+	})
+})
+
+var _ = Describe("Table", func() {
+	It("Set and Get", func() {
+		t := NewTable()
+		Ω(t.Set("USD", "CNY", decimal.FromInt(7))).Should(Succeed())
+		rate, ok := t.Get("USD", "CNY")
+		Ω(ok).Should(BeTrue())
+		Ω(rate).Should(Equal(decimal.FromInt(7)))
+		_, ok = t.Get("CNY", "USD")
+		Ω(ok).Should(BeFalse())
+
+		Ω(t.Set("USD", "CNY", decimal.Zero(2))).Should(MatchError("[math-currency] USD/CNY rate 0.00 not positive"))
+	})
+
+	It("zero value", func() {
+		var t Table
+		_, ok := t.Get("USD", "CNY")
+		Ω(ok).Should(BeFalse())
+		Ω(t.Set("USD", "CNY", decimal.FromInt(7))).Should(Succeed())
+		rate, ok := t.Get("USD", "CNY")
+		Ω(ok).Should(BeTrue())
+		Ω(rate).Should(Equal(decimal.FromInt(7)))
+	})
+
+	It("LoadCSV columns in any order", func() {
+		t, err := LoadCSV(strings.NewReader("date,Rate,To,From\n2023-01-01, 7.1 ,CNY,USD\n"))
+		Ω(err).Should(Succeed())
+		rate, _ := t.Get("USD", "CNY")
+		Ω(rate.String()).Should(Equal("7.1"))
+	})
+
+	DescribeTable("LoadCSV error", func(csv, exp string) {
+		_, err := LoadCSV(strings.NewReader(csv))
+		Ω(err).Should(MatchError(exp))
+	},
+		Entry("empty", "", "[math-currency] missing CSV header"),
+		Entry("missing column", "from,to\n", `[math-currency] missing "rate" column in CSV header`),
+		Entry("short row", "from,to,rate\nUSD,CNY\n", "[math-currency] line 2: expect at least 3 columns"),
+		Entry("bad rate", "from,to,rate\nUSD,CNY,abc\n",
+			`[math-currency] line 2: [decimal] "abc" not a number, unexpected 'a' at position 0`),
+		Entry("negative rate", "from,to,rate\nUSD,CNY,1\nUSD,JPY,-1\n",
+			"[math-currency] line 3: USD/JPY rate -1 not positive"),
+		Entry("line of quoted newline", "from,to,rate\n\"US\nD\",CNY,7\nUSD,JPY,abc\n",
+			`[math-currency] line 4: [decimal] "abc" not a number, unexpected 'a' at position 0`),
+	)
+})
@@ -0,0 +1,21 @@
+package currency_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/testing/matcher"
+
+	"testing"
+)
+
+func TestCurrency(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Currency Suite")
+}
+
+// toDecimal parse decimal string, fails the test if s not a number.
+func toDecimal(s string) (d decimal.Decimal) {
+	Ω(decimal.FromString(s)).Should(matcher.Save(&d))
+	return
+}
@@ -0,0 +1,6 @@
+// Package currency converts amounts between currencies by exchange-rate
+// tables, triangulates through a base currency if no direct rate, and
+// rounds results to the minor unit of the target currency.
+package currency
+
+const tag = "math-currency"
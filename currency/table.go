@@ -0,0 +1,105 @@
+package currency
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/redforks/math/decimal"
+)
+
+// Table is a table of exchange rates, the zero value is an empty table ready
+// to use. Table is not safe for concurrent modification.
+type Table struct {
+	rates map[pair]decimal.Decimal
+}
+
+type pair struct {
+	from, to string
+}
+
+// NewTable creates an empty Table.
+func NewTable() *Table {
+	return &Table{rates: map[pair]decimal.Decimal{}}
+}
+
+// Set sets exchange rate, 1 unit of from currency equals to rate units of to
+// currency. Returns error if rate is not positive.
+func (t *Table) Set(from, to string, rate decimal.Decimal) error {
+	if rate.Sign() <= 0 {
+		return fmt.Errorf("[%s] %s/%s rate %s not positive", tag, from, to, rate)
+	}
+	if t.rates == nil {
+		t.rates = map[pair]decimal.Decimal{}
+	}
+	t.rates[pair{from, to}] = rate
+	return nil
+}
+
+// Get returns exchange rate set by Set(), ok is false if not exist. Get does
+// not invert or triangulate rates.
+func (t *Table) Get(from, to string) (rate decimal.Decimal, ok bool) {
+	rate, ok = t.rates[pair{from, to}]
+	return
+}
+
+// LoadCSV loads exchange rates from CSV. The first row is header, must
+// contains "from", "to" and "rate" columns in any order, other columns
+// ignored, such as:
+//
+//	from,to,rate
+//	USD,CNY,7.1234
+//	EUR,USD,1.0856
+func LoadCSV(r io.Reader) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("[%s] missing CSV header", tag)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %s", tag, err)
+	}
+
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	var idx [3]int
+	for i, name := range []string{"from", "to", "rate"} {
+		c, ok := cols[name]
+		if !ok {
+			return nil, fmt.Errorf("[%s] missing %q column in CSV header", tag, name)
+		}
+		idx[i] = c
+	}
+
+	t := NewTable()
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return t, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("[%s] %s", tag, err)
+		}
+
+		// line of the record start, quoted fields may span lines
+		line, _ := reader.FieldPos(0)
+		if len(record) <= idx[0] || len(record) <= idx[1] || len(record) <= idx[2] {
+			return nil, fmt.Errorf("[%s] line %d: expect at least %d columns", tag, line, len(header))
+		}
+		from, to := strings.TrimSpace(record[idx[0]]), strings.TrimSpace(record[idx[1]])
+		rate, err := decimal.FromString(record[idx[2]])
+		if err != nil {
+			return nil, fmt.Errorf("[%s] line %d: %s", tag, line, err)
+		}
+		if rate.Sign() <= 0 {
+			return nil, fmt.Errorf("[%s] line %d: %s/%s rate %s not positive", tag, line, from, to, rate)
+		}
+		t.rates[pair{from, to}] = rate
+	}
+}
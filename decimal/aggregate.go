@@ -0,0 +1,189 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ErrEmpty indicates that an aggregate function got no values.
+var ErrEmpty = fmt.Errorf("[%s] no values to aggregate", tag)
+
+// Sum returns sum of values, use the highest scale of values as result scale.
+// Intermediate sums never overflow, returns ErrOverflow if the final result
+// out of range. Returns Zero(0) if values is empty.
+func Sum(values []Decimal) (Decimal, error) {
+	scale := maxScaleOf(values)
+	return fromBig(sumBig(values, scale), scale)
+}
+
+// Avg returns arithmetic mean of values round to specific scale. Returns
+// ErrEmpty if values is empty.
+func Avg(values []Decimal, scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+	if len(values) == 0 {
+		return Decimal{}, ErrEmpty
+	}
+
+	sumScale := maxScaleOf(values)
+	return quoToScale(sumBig(values, sumScale), sumScale, big.NewInt(int64(len(values))), 0, scale, HalfUp)
+}
+
+// Min returns the smallest value, the first one if there are equal values in
+// different scales. Returns ErrEmpty if values is empty.
+func Min(values []Decimal) (Decimal, error) {
+	if len(values) == 0 {
+		return Decimal{}, ErrEmpty
+	}
+
+	r := values[0]
+	for _, v := range values[1:] {
+		if v.LT(r) {
+			r = v
+		}
+	}
+	return r, nil
+}
+
+// Max returns the largest value, the first one if there are equal values in
+// different scales. Returns ErrEmpty if values is empty.
+func Max(values []Decimal) (Decimal, error) {
+	if len(values) == 0 {
+		return Decimal{}, ErrEmpty
+	}
+
+	r := values[0]
+	for _, v := range values[1:] {
+		if v.GT(r) {
+			r = v
+		}
+	}
+	return r, nil
+}
+
+// WeightedAvg returns sum(values[i] * weights[i]) / sum(weights) round to
+// specific scale. Returns ErrEmpty if values is empty, ErrDivisionByZero if sum
+// of weights is zero.
+func WeightedAvg(values, weights []Decimal, scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+	if len(values) != len(weights) {
+		return Decimal{}, fmt.Errorf("[%s] %d values but %d weights", tag, len(values), len(weights))
+	}
+	if len(values) == 0 {
+		return Decimal{}, ErrEmpty
+	}
+
+	weightScale := maxScaleOf(weights)
+	den := sumBig(weights, weightScale)
+	if den.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+
+	numScale := maxScaleOf(values) + weightScale
+	num, t := new(big.Int), new(big.Int)
+	for i, v := range values {
+		t.Mul(toBig(v, int(v.scale)), toBig(weights[i], weightScale))
+		num.Add(num, t.Mul(t, bigPowerOf10(numScale-int(v.scale)-weightScale)))
+	}
+	return quoToScale(num, numScale, den, weightScale, scale, HalfUp)
+}
+
+// Median returns the middle value of sorted values. If count of values is
+// even, returns exact mean of two middle values, scale of the result may one
+// greater than them, such as median of 1 and 2 is 1.5. Returns ErrEmpty if
+// values is empty.
+func Median(values []Decimal) (Decimal, error) {
+	if len(values) == 0 {
+		return Decimal{}, ErrEmpty
+	}
+
+	sorted := make([]Decimal, len(values))
+	copy(sorted, values)
+	Sort(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2], nil
+	}
+
+	middle := sorted[n/2-1 : n/2+1]
+	scale := maxScaleOf(middle)
+	sum := sumBig(middle, scale)
+	if sum.Bit(0) == 0 {
+		return fromBig(sum.Rsh(sum, 1), scale)
+	}
+
+	if err := checkScale(scale + 1); err != nil {
+		return Decimal{}, err
+	}
+	return fromBig(sum.Mul(sum, big.NewInt(5)), scale+1)
+}
+
+// maxScaleOf returns the highest scale of values, 0 if values is empty.
+func maxScaleOf(values []Decimal) int {
+	scale := 0
+	for _, v := range values {
+		if int(v.scale) > scale {
+			scale = int(v.scale)
+		}
+	}
+	return scale
+}
+
+// sumBig returns sum of values aligned to scale, scale must not less than
+// scale of any value.
+func sumBig(values []Decimal, scale int) *big.Int {
+	r := new(big.Int)
+	for _, v := range values {
+		r.Add(r, toBig(v, scale))
+	}
+	return r
+}
+
+// toBig returns digits of d aligned to scale, scale must not less than d.scale.
+func toBig(d Decimal, scale int) *big.Int {
+	r := big.NewInt(d.digits)
+	if diff := scale - int(d.scale); diff > 0 {
+		r.Mul(r, bigPowerOf10(diff))
+	}
+	return r
+}
+
+// fromBig create Decimal from digits and scale, returns ErrOverflow if digits
+// out of int64 range.
+func fromBig(digits *big.Int, scale int) (Decimal, error) {
+	if !digits.IsInt64() {
+		return Decimal{}, ErrOverflow
+	}
+	return Decimal{digits.Int64(), uint8(scale)}, nil
+}
+
+// quoToScale returns (num / 10^numScale) / (den / 10^denScale) round to scale
+// using rounding mode. den must not be zero.
+func quoToScale(num *big.Int, numScale int, den *big.Int, denScale, scale int, mode RoundingMode) (Decimal, error) {
+	n, m := new(big.Int).Set(num), new(big.Int).Set(den)
+	if diff := scale + denScale - numScale; diff > 0 {
+		n.Mul(n, bigPowerOf10(diff))
+	} else if diff < 0 {
+		m.Mul(m, bigPowerOf10(-diff))
+	}
+
+	q, r := n.QuoRem(n, m, new(big.Int))
+	neg, exact := num.Sign() != den.Sign(), r.Sign() == 0
+	if mode.away(neg, q.Bit(0) == 1, exact, r.Lsh(r.Abs(r), 1).Cmp(m.Abs(m))) {
+		if neg {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return fromBig(q, scale)
+}
+
+// bigPowerOf10 returns 10^n as big.Int.
+func bigPowerOf10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
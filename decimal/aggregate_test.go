@@ -0,0 +1,108 @@
+package decimal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+)
+
+var _ = Describe("Aggregate", func() {
+	DescribeTable("Sum", func(values, exp string) {
+		Ω(decimal.Sum(toDecimals(values))).Should(Equal(toDecimal(exp)))
+	},
+		Entry("empty", "", "0"),
+		Entry("one", "3.30", "3.30"),
+		Entry("different scales", "1 0.5 0.25", "1.75"),
+		Entry("negative", "1 -3.5", "-2.5"),
+		Entry("intermediate overflow", "9223372036854775807 1 -2", "9223372036854775806"),
+		Entry("intermediate overflow when align", "922337203685477580.7 100 -100", "922337203685477580.7"),
+	)
+
+	It("Sum overflow", func() {
+		_, err := decimal.Sum(toDecimals("9223372036854775807 1"))
+		Ω(err).Should(Equal(decimal.ErrOverflow))
+
+		_, err = decimal.Sum(toDecimals("922337203685477580 0.01"))
+		Ω(err).Should(Equal(decimal.ErrOverflow))
+	})
+
+	DescribeTable("Avg", func(values string, scale int, exp string) {
+		Ω(decimal.Avg(toDecimals(values), scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("one", "3.30", 2, "3.30"),
+		Entry("exact", "1 2 3", 0, "2"),
+		Entry("round up", "1 2", 0, "2"),
+		Entry("round down", "1 1 2", 0, "1"),
+		Entry("round up negative", "-1 -2", 0, "-2"),
+		Entry("expand scale", "1 2", 2, "1.50"),
+		Entry("repeating", "1 0 0", 4, "0.3333"),
+		Entry("intermediate overflow", "9223372036854775807 9223372036854775807", 0, "9223372036854775807"),
+	)
+
+	It("Avg error", func() {
+		_, err := decimal.Avg(nil, 2)
+		Ω(err).Should(Equal(decimal.ErrEmpty))
+
+		_, err = decimal.Avg(toDecimals("1"), 19)
+		Ω(err).Should(MatchError("[decimal] scale 19 out of range"))
+	})
+
+	DescribeTable("Min and Max", func(values, min, max string) {
+		Ω(decimal.Min(toDecimals(values))).Should(Equal(toDecimal(min)))
+		Ω(decimal.Max(toDecimals(values))).Should(Equal(toDecimal(max)))
+	},
+		Entry("one", "3.30", "3.30", "3.30"),
+		Entry("many", "3 -1.5 10.01 2", "-1.5", "10.01"),
+		Entry("equal values keep the first", "1.0 1.00 1", "1.0", "1.0"),
+	)
+
+	It("Min and Max empty", func() {
+		_, err := decimal.Min(nil)
+		Ω(err).Should(Equal(decimal.ErrEmpty))
+		_, err = decimal.Max(nil)
+		Ω(err).Should(Equal(decimal.ErrEmpty))
+	})
+
+	DescribeTable("WeightedAvg", func(values, weights string, scale int, exp string) {
+		Ω(decimal.WeightedAvg(toDecimals(values), toDecimals(weights), scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("equal weights", "1 2", "1 1", 1, "1.5"),
+		Entry("weighted", "10 20", "3 1", 2, "12.50"),
+		Entry("fragment weights", "10.5 20", "0.25 0.75", 2, "17.63"),
+		Entry("round down", "1 2", "2 1", 2, "1.33"),
+		Entry("negative", "-1 -2", "1 2", 2, "-1.67"),
+		Entry("intermediate overflow", "9223372036854775807 9223372036854775807", "1000 1000", 0, "9223372036854775807"),
+	)
+
+	It("WeightedAvg error", func() {
+		_, err := decimal.WeightedAvg(nil, nil, 2)
+		Ω(err).Should(Equal(decimal.ErrEmpty))
+
+		_, err = decimal.WeightedAvg(toDecimals("1 2"), toDecimals("1 -1"), 2)
+		Ω(err).Should(Equal(decimal.ErrDivisionByZero))
+
+		_, err = decimal.WeightedAvg(toDecimals("1 2"), toDecimals("1"), 2)
+		Ω(err).Should(MatchError("[decimal] 2 values but 1 weights"))
+	})
+
+	DescribeTable("Median", func(values, exp string) {
+		Ω(decimal.Median(toDecimals(values))).Should(Equal(toDecimal(exp)))
+	},
+		Entry("one", "3.30", "3.30"),
+		Entry("odd", "3 1 2", "2"),
+		Entry("even", "4 1 3 2", "2.5"),
+		Entry("even exact", "4 1 3 1", "2"),
+		Entry("even different scale", "1.00 2.1", "1.55"),
+		Entry("even negative", "-1 -2", "-1.5"),
+		Entry("even overflow", "9223372036854775807 9223372036854775807", "9223372036854775807"),
+	)
+
+	It("Median error", func() {
+		_, err := decimal.Median(nil)
+		Ω(err).Should(Equal(decimal.ErrEmpty))
+
+		_, err = decimal.Median(toDecimals("0.000000000000000001 0.000000000000000002"))
+		Ω(err).Should(MatchError("[decimal] scale 19 out of range"))
+	})
+})
@@ -0,0 +1,50 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/redforks/math/decimal"
+)
+
+func BenchmarkString(b *testing.B) {
+	d := decimal.New(-1234567, 4)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = d.String()
+	}
+}
+
+func BenchmarkAppendString(b *testing.B) {
+	d, buf := decimal.New(-1234567, 4), make([]byte, 0, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = d.AppendString(buf[:0])
+	}
+}
+
+func BenchmarkAppendShortString(b *testing.B) {
+	d, buf := decimal.New(-1234500, 4), make([]byte, 0, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = d.AppendShortString(buf[:0])
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	text := []byte("-123.4567")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := decimal.ParseBytes(text); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFromString(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := decimal.FromString("-123.4567"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
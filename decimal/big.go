@@ -0,0 +1,110 @@
+package decimal
+
+import "math/big"
+
+// ToBigRat returns the exact value of d as big.Rat.
+func (d Decimal) ToBigRat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(d.digits), bigPowerOf10(int(d.scale)))
+}
+
+// ToBigInt returns d * 10^scale as big.Int, the coefficient of d at specific
+// scale, such as 1.5 at scale 2 is 150, at scale 0 is ErrPrecisionLoss.
+// Unlike Rescale(), scale may greater than MaxScale, returns error if scale is
+// negative.
+func (d Decimal) ToBigInt(scale int) (*big.Int, error) {
+	if scale < 0 {
+		return nil, checkScale(scale)
+	}
+
+	i := big.NewInt(d.digits)
+	diff := scale - int(d.scale)
+	if diff >= 0 {
+		return i.Mul(i, bigPowerOf10(diff)), nil
+	}
+
+	var r big.Int
+	if i.QuoRem(i, bigPowerOf10(-diff), &r); r.Sign() != 0 {
+		return nil, ErrPrecisionLoss
+	}
+	return i, nil
+}
+
+// ToBigFloat returns d as big.Float of specific precision in bits, rounded to
+// nearest even. Precision 0 is 64, the precision of big.Float.SetInt64().
+func (d Decimal) ToBigFloat(prec uint) *big.Float {
+	if prec == 0 {
+		prec = 64
+	}
+
+	// round the exact value once, SetInt64() then Quo() rounds twice if prec
+	// less than 64.
+	return new(big.Float).SetPrec(prec).SetRat(d.ToBigRat())
+}
+
+// FromBigRat round r to specific scale using rounding mode. Returns error if
+// scale out of range, ErrOverflow if digits of result out of int64 range.
+func FromBigRat(r *big.Rat, scale int, mode RoundingMode) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+
+	// |r| * 10^scale = q + rem / denom
+	neg := r.Sign() < 0
+	n := new(big.Int).Abs(r.Num())
+	q, rem := new(big.Int).QuoRem(n.Mul(n, bigPowerOf10(scale)), r.Denom(), new(big.Int))
+	half := rem.Lsh(rem, 1).Cmp(r.Denom())
+	if mode.away(neg, q.Bit(0) == 1, rem.Sign() == 0, half) {
+		q.Add(q, big.NewInt(1))
+	}
+	if neg {
+		q.Neg(q)
+	}
+
+	if !q.IsInt64() {
+		return Decimal{}, ErrOverflow
+	}
+	return Decimal{q.Int64(), uint8(scale)}, nil
+}
+
+// FromBigInt returns Decimal of i * 10^-scale, inverse of ToBigInt(), such as
+// 150 at scale 2 is 1.50. Scale greater than MaxScale reduced to MaxScale if
+// digits beyond are zeros, otherwise returns ErrPrecisionLoss. Returns
+// ErrOverflow if out of range, error if scale is negative.
+func FromBigInt(i *big.Int, scale int) (Decimal, error) {
+	if scale < 0 {
+		return Decimal{}, checkScale(scale)
+	}
+
+	if scale > MaxScale {
+		var q, r big.Int
+		if q.QuoRem(i, bigPowerOf10(scale-MaxScale), &r); r.Sign() != 0 {
+			return Decimal{}, ErrPrecisionLoss
+		}
+		i, scale = &q, MaxScale
+	}
+
+	if !i.IsInt64() {
+		return Decimal{}, ErrOverflow
+	}
+	return Decimal{i.Int64(), uint8(scale)}, nil
+}
+
+// FromBigFloat round f to specific scale using rounding mode, rounding based
+// on the exact value of f. Returns error if scale out of range, ErrOverflow if
+// f is infinity or digits of result out of int64 range.
+func FromBigFloat(f *big.Float, scale int, mode RoundingMode) (Decimal, error) {
+	if f.IsInf() {
+		return Decimal{}, ErrOverflow
+	}
+
+	// avoid huge big.Rat of extreme exponents: |f| >= 2^64 always overflows,
+	// |f| < 2^-100 rounds the same as 2^-100, far less than half of 10^-MaxScale.
+	switch exp := f.MantExp(nil); {
+	case f.Sign() != 0 && exp > 64:
+		return Decimal{}, ErrOverflow
+	case f.Sign() != 0 && exp < -100:
+		f = new(big.Float).SetMantExp(big.NewFloat(float64(f.Sign())), -100)
+	}
+	r, _ := f.Rat(nil)
+	return FromBigRat(r, scale, mode)
+}
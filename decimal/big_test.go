@@ -0,0 +1,172 @@
+package decimal_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+)
+
+var _ = Describe("Big", func() {
+	toRat := func(s string) *big.Rat {
+		r, ok := new(big.Rat).SetString(s)
+		Ω(ok).Should(BeTrue())
+		return r
+	}
+
+	toInt := func(s string) *big.Int {
+		i, ok := new(big.Int).SetString(s, 10)
+		Ω(ok).Should(BeTrue())
+		return i
+	}
+
+	DescribeTable("ToBigRat", func(s, exp string) {
+		Ω(toDecimal(s).ToBigRat().RatString()).Should(Equal(exp))
+	},
+		Entry("zero", "0.00", "0"),
+		Entry("integer", "-12", "-12"),
+		Entry("fraction", "1.50", "3/2"),
+		Entry("max scale", "-0.000000000000000001", "-1/1000000000000000000"),
+		Entry("max", "9223372036854775807", "9223372036854775807"),
+		Entry("min", "-9.223372036854775808", "-35184372088832/3814697265625"),
+	)
+
+	DescribeTable("ToBigInt", func(s string, scale int, exp string, expErr error) {
+		i, err := toDecimal(s).ToBigInt(scale)
+		if expErr != nil {
+			Ω(err).Should(Equal(expErr))
+			return
+		}
+		Ω(err).Should(Succeed())
+		Ω(i.String()).Should(Equal(exp))
+	},
+		Entry("same scale", "1.50", 2, "150", nil),
+		Entry("scale up", "-1.5", 3, "-1500", nil),
+		Entry("scale down", "1.50", 1, "15", nil),
+		Entry("to integer", "-12.000", 0, "-12", nil),
+		Entry("beyond max scale", "1", 20, "100000000000000000000", nil),
+		Entry("beyond int64", "-9223372036854775808", 2, "-922337203685477580800", nil),
+		Entry("precision loss", "1.5", 0, "", decimal.ErrPrecisionLoss),
+	)
+
+	It("ToBigInt negative scale", func() {
+		_, err := decimal.FromInt(1).ToBigInt(-1)
+		Ω(err).Should(MatchError("[decimal] scale -1 out of range"))
+	})
+
+	DescribeTable("ToBigFloat", func(s string, prec uint, exp string) {
+		f := toDecimal(s).ToBigFloat(prec)
+		Ω(f.Text('g', 30)).Should(Equal(exp))
+	},
+		Entry("integer", "-12", uint(0), "-12"),
+		Entry("exact", "1.50", uint(0), "1.5"),
+		Entry("max", "9223372036854775807", uint(0), "9223372036854775807"),
+		Entry("default precision", "0.1", uint(0), "0.100000000000000000001355252716"),
+		Entry("float64 precision", "0.1", uint(53), "0.100000000000000005551115123126"),
+		Entry("round once 2 bits", "1.9", uint(2), "2"),
+		Entry("round once 1 bit", "0.7", uint(1), "0.5"),
+		Entry("round integer", "-7", uint(2), "-8"),
+		Entry("half even", "1.25", uint(2), "1"),
+	)
+
+	It("ToBigFloat precision", func() {
+		Ω(decimal.FromInt(1).ToBigFloat(0).Prec()).Should(Equal(uint(64)))
+		Ω(decimal.FromInt(1).ToBigFloat(200).Prec()).Should(Equal(uint(200)))
+	})
+
+	DescribeTable("FromBigRat", func(r string, scale int, mode decimal.RoundingMode, exp string) {
+		Ω(decimal.FromBigRat(toRat(r), scale, mode)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("exact", "3/2", 2, decimal.Down, "1.50"),
+		Entry("half up", "5/2", 0, decimal.HalfUp, "3"),
+		Entry("half up negative", "-5/2", 0, decimal.HalfUp, "-3"),
+		Entry("half down", "5/2", 0, decimal.HalfDown, "2"),
+		Entry("half down above half", "251/100", 0, decimal.HalfDown, "3"),
+		Entry("half even to even", "5/2", 0, decimal.HalfEven, "2"),
+		Entry("half even from odd", "7/2", 0, decimal.HalfEven, "4"),
+		Entry("up", "1/3", 2, decimal.Up, "0.34"),
+		Entry("up negative", "-1/3", 2, decimal.Up, "-0.34"),
+		Entry("down", "2/3", 2, decimal.Down, "0.66"),
+		Entry("ceiling", "-2/3", 2, decimal.Ceiling, "-0.66"),
+		Entry("floor", "-1/3", 2, decimal.Floor, "-0.34"),
+		Entry("tiny", "1/100000000000000000000", 18, decimal.HalfUp, "0.000000000000000000"),
+		Entry("max", "9223372036854775807", 0, decimal.HalfUp, "9223372036854775807"),
+		Entry("min", "-92233720368547758081/10", 0, decimal.HalfUp, "-9223372036854775808"),
+	)
+
+	DescribeTable("FromBigRat error", func(r string, scale int, errMsg string) {
+		_, err := decimal.FromBigRat(toRat(r), scale, decimal.HalfUp)
+		Ω(err).Should(MatchError(errMsg))
+	},
+		Entry("overflow", "9223372036854775808", 0, decimal.ErrOverflow.Error()),
+		Entry("overflow by rounding", "18446744073709551615/2", 0, decimal.ErrOverflow.Error()),
+		Entry("overflow by scale", "1/3", 19, "[decimal] scale 19 out of range"),
+		Entry("negative scale", "1", -1, "[decimal] scale -1 out of range"),
+	)
+
+	DescribeTable("FromBigInt", func(i string, scale int, exp string, expErr error) {
+		d, err := decimal.FromBigInt(toInt(i), scale)
+		if expErr != nil {
+			Ω(err).Should(Equal(expErr))
+			return
+		}
+		Ω(err).Should(Succeed())
+		Ω(d).Should(Equal(toDecimal(exp)))
+	},
+		Entry("integer", "-12", 0, "-12", nil),
+		Entry("fraction", "150", 2, "1.50", nil),
+		Entry("max scale", "1", 18, "0.000000000000000001", nil),
+		Entry("reduce scale", "100", 20, "0.000000000000000001", nil),
+		Entry("precision loss", "1", 19, "", decimal.ErrPrecisionLoss),
+		Entry("min", "-9223372036854775808", 0, "-9223372036854775808", nil),
+		Entry("overflow", "9223372036854775808", 0, "", decimal.ErrOverflow),
+	)
+
+	It("FromBigInt negative scale", func() {
+		_, err := decimal.FromBigInt(big.NewInt(1), -1)
+		Ω(err).Should(MatchError("[decimal] scale -1 out of range"))
+	})
+
+	DescribeTable("FromBigFloat", func(f *big.Float, scale int, mode decimal.RoundingMode, exp string) {
+		Ω(decimal.FromBigFloat(f, scale, mode)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("zero", new(big.Float), 2, decimal.HalfUp, "0.00"),
+		Entry("exact", big.NewFloat(1.5), 2, decimal.HalfUp, "1.50"),
+		Entry("exact value of float64", big.NewFloat(0.1), 18, decimal.HalfUp, "0.100000000000000006"),
+		Entry("half even", big.NewFloat(2.5), 0, decimal.HalfEven, "2"),
+		Entry("floor", big.NewFloat(-0.125), 2, decimal.Floor, "-0.13"),
+		Entry("tiny up", new(big.Float).SetMantExp(big.NewFloat(1), -1000), 18, decimal.Up, "0.000000000000000001"),
+		Entry("tiny floor", new(big.Float).SetMantExp(big.NewFloat(-1), -1000), 2, decimal.Floor, "-0.01"),
+		Entry("tiny half up", new(big.Float).SetMantExp(big.NewFloat(1), -1000), 18, decimal.HalfUp, "0.000000000000000000"),
+		Entry("max", new(big.Float).SetInt64(math.MaxInt64), 0, decimal.HalfUp, "9223372036854775807"),
+	)
+
+	DescribeTable("FromBigFloat error", func(f *big.Float, scale int) {
+		_, err := decimal.FromBigFloat(f, scale, decimal.HalfUp)
+		Ω(err).Should(Equal(decimal.ErrOverflow))
+	},
+		Entry("infinity", new(big.Float).SetInf(false), 0),
+		Entry("negative infinity", new(big.Float).SetInf(true), 0),
+		Entry("huge", new(big.Float).SetMantExp(big.NewFloat(1), 1000), 0),
+		Entry("2^63", new(big.Float).SetMantExp(big.NewFloat(1), 63), 0),
+		Entry("overflow by scale", big.NewFloat(1e17), 2),
+	)
+
+	It("round trip", func() {
+		for i := 0; i < 1000; i++ {
+			scale := rand.Intn(decimal.MaxScale + 1)
+			d := decimal.New(rand.Int63()-rand.Int63(), scale)
+			Ω(decimal.FromBigRat(d.ToBigRat(), scale, decimal.HalfEven)).Should(Equal(d))
+
+			bi, err := d.ToBigInt(scale)
+			Ω(err).Should(Succeed())
+			Ω(decimal.FromBigInt(bi, scale)).Should(Equal(d))
+
+			Ω(decimal.FromBigFloat(d.ToBigFloat(128), scale, decimal.HalfEven)).Should(Equal(d))
+		}
+	})
+})
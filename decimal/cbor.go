@@ -0,0 +1,237 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+)
+
+// CBOR major types and tags, see RFC 8949.
+const (
+	cborUint      = 0
+	cborNegInt    = 1
+	cborBytes     = 2
+	cborText      = 3
+	cborArray     = 4
+	cborTag       = 6
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+
+	cborTagPosBignum       = 2
+	cborTagNegBignum       = 3
+	cborTagDecimalFraction = 4
+)
+
+// MarshalCBOR implement cbor.Marshaler interface, encoded as decimal fraction
+// (tag 4) [exponent, mantissa], exponent is -scale, such as 1.50 encoded as
+// 4([-2, 150]).
+func (d Decimal) MarshalCBOR() ([]byte, error) {
+	buf := make([]byte, 0, 13)
+	buf = append(buf, cborTag<<5|cborTagDecimalFraction, cborArray<<5|2)
+	buf = appendCBORInt(buf, -int64(d.scale))
+	return appendCBORInt(buf, d.digits), nil
+}
+
+// UnmarshalCBOR implement cbor.Unmarshaler interface, decode decimal fraction
+// (tag 4) with integer or bignum mantissa. Also accepts integer, and text
+// parsed by FromString(), so that values stored before as string can be
+// decoded.
+//
+// Returns ErrPrecisionLoss if exponent less than -MaxScale and mantissa has
+// non-zero digits beyond, ErrOverflow if value out of range.
+func (d *Decimal) UnmarshalCBOR(data []byte) error {
+	major, v, n, ok := cborHead(data)
+	if !ok {
+		return fmt.Errorf("[%s] invalid CBOR data %x", tag, data)
+	}
+
+	rest := data[n:]
+	switch {
+	case major == cborUint || major == cborNegInt:
+		if len(rest) != 0 {
+			break
+		}
+		neg, u := major == cborNegInt, uint128{lo: v}
+		if neg {
+			u = u.add(1)
+		}
+		digits, ok := u.toInt64(neg)
+		if !ok {
+			return ErrOverflow
+		}
+		*d = FromInt(digits)
+		return nil
+
+	case major == cborText:
+		if uint64(len(rest)) != v {
+			break
+		}
+		r, err := ParseBytes(rest)
+		if err != nil {
+			return err
+		}
+		*d = r
+		return nil
+
+	case major == cborTag && v == cborTagDecimalFraction:
+		return d.unmarshalCBORDecimalFraction(rest, data)
+	}
+	return fmt.Errorf("[%s] can not decode CBOR %x to Decimal", tag, data)
+}
+
+func (d *Decimal) unmarshalCBORDecimalFraction(b, data []byte) error {
+	major, v, n, ok := cborHead(b)
+	if !ok || major != cborArray || v != 2 {
+		return fmt.Errorf("[%s] invalid CBOR decimal fraction %x", tag, data)
+	}
+	b = b[n:]
+
+	// exponent
+	major, v, n, ok = cborHead(b)
+	if !ok || major != cborUint && major != cborNegInt || v > math.MaxInt32 {
+		return fmt.Errorf("[%s] invalid CBOR decimal fraction %x", tag, data)
+	}
+	exp := int(v)
+	if major == cborNegInt {
+		exp = -1 - exp
+	}
+	b = b[n:]
+
+	// mantissa
+	major, v, n, ok = cborHead(b)
+	if !ok {
+		return fmt.Errorf("[%s] invalid CBOR decimal fraction %x", tag, data)
+	}
+	b = b[n:]
+	var neg bool
+	switch {
+	case major == cborUint || major == cborNegInt:
+		neg = major == cborNegInt
+	case major == cborTag && (v == cborTagPosBignum || v == cborTagNegBignum):
+		neg = v == cborTagNegBignum
+		if major, v, n, ok = cborHead(b); !ok || major != cborBytes || uint64(len(b)-n) != v {
+			return fmt.Errorf("[%s] invalid CBOR decimal fraction %x", tag, data)
+		}
+		b = b[n:]
+		v = 0
+		for _, c := range b {
+			if v > math.MaxUint64>>8 {
+				return ErrOverflow
+			}
+			v = v<<8 | uint64(c)
+		}
+		b = nil
+	default:
+		return fmt.Errorf("[%s] invalid CBOR decimal fraction %x", tag, data)
+	}
+	if len(b) != 0 {
+		return fmt.Errorf("[%s] invalid CBOR decimal fraction %x", tag, data)
+	}
+
+	u := uint128{lo: v}
+	if neg {
+		u = u.add(1)
+	}
+
+	scale := 0
+	switch {
+	case u == uint128{}:
+		if exp < 0 {
+			scale = -exp
+		}
+		if scale > MaxScale {
+			scale = MaxScale
+		}
+	case exp > 0:
+		if u, ok = u.mulPow10(exp); !ok {
+			return ErrOverflow
+		}
+	default:
+		scale = -exp
+		for ; scale > MaxScale; scale-- {
+			var r uint64
+			if u, r = u.quoRem(10); r != 0 {
+				return ErrPrecisionLoss
+			}
+		}
+	}
+
+	digits, ok := u.toInt64(neg)
+	if !ok {
+		return ErrOverflow
+	}
+	*d = Decimal{digits, uint8(scale)}
+	return nil
+}
+
+// cborHead decodes head of a CBOR data item, returns major type, argument
+// value and length of the head. ok is false if b is malformed or of
+// indefinite length.
+func cborHead(b []byte) (major byte, v uint64, n int, ok bool) {
+	if len(b) == 0 {
+		return 0, 0, 0, false
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return major, uint64(info), 1, true
+	case info <= 27:
+		n = 1 << (info - 24)
+		if len(b) < 1+n {
+			return 0, 0, 0, false
+		}
+		for _, c := range b[1 : 1+n] {
+			v = v<<8 | uint64(c)
+		}
+		return major, v, 1 + n, true
+	default:
+		return 0, 0, 0, false
+	}
+}
+
+// appendCBORInt appends v as CBOR unsigned or negative integer in the
+// shortest form.
+func appendCBORInt(buf []byte, v int64) []byte {
+	major, u := byte(cborUint), uint64(v)
+	if v < 0 {
+		major, u = cborNegInt, uint64(-1-v)
+	}
+
+	major <<= 5
+	switch {
+	case u < 24:
+		return append(buf, major|byte(u))
+	case u <= math.MaxUint8:
+		return append(buf, major|24, byte(u))
+	case u <= math.MaxUint16:
+		return append(buf, major|25, byte(u>>8), byte(u))
+	case u <= math.MaxUint32:
+		return append(buf, major|26, byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+	default:
+		return append(buf, major|27, byte(u>>56), byte(u>>48), byte(u>>40), byte(u>>32),
+			byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+	}
+}
+
+// MarshalCBOR implement cbor.Marshaler interface, NULL encoded as null.
+func (d NullDecimal) MarshalCBOR() ([]byte, error) {
+	if !d.Valid {
+		return []byte{cborNull}, nil
+	}
+	return d.Decimal.MarshalCBOR()
+}
+
+// UnmarshalCBOR implement cbor.Unmarshaler interface, null and undefined
+// decoded to NULL.
+func (d *NullDecimal) UnmarshalCBOR(data []byte) error {
+	if len(data) == 1 && (data[0] == cborNull || data[0] == cborUndefined) {
+		d.Valid = false
+		d.Decimal = Zero(0)
+		return nil
+	}
+
+	if err := d.Decimal.UnmarshalCBOR(data); err != nil {
+		return err
+	}
+	d.Valid = true
+	return nil
+}
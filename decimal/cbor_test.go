@@ -0,0 +1,130 @@
+package decimal_test
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/testing/matcher"
+)
+
+var _ = Describe("CBOR", func() {
+	DescribeTable("decimal fraction", func(s string, exp []byte) {
+		var d, back decimal.Decimal
+		Ω(decimal.FromString(s)).Should(matcher.Save(&d))
+		Ω(d.MarshalCBOR()).Should(Equal(exp))
+
+		Ω(back.UnmarshalCBOR(exp)).Should(Succeed())
+		Ω(back).Should(Equal(d))
+	},
+		Entry("zero", "0", []byte{0xc4, 0x82, 0x00, 0x00}),
+		Entry("-1.0", "-1.0", []byte{0xc4, 0x82, 0x20, 0x29}),
+		Entry("1.50", "1.50", []byte{0xc4, 0x82, 0x21, 0x18, 0x96}),
+		// example of RFC 8949 section 3.4.4
+		Entry("273.15", "273.15", []byte{0xc4, 0x82, 0x21, 0x19, 0x6a, 0xb3}),
+		Entry("uint32", "-0.000000000000100000", []byte{0xc4, 0x82, 0x31, 0x3a, 0x00, 0x01, 0x86, 0x9f}),
+		Entry("max int64", "9223372036854775807",
+			[]byte{0xc4, 0x82, 0x00, 0x1b, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}),
+		Entry("min int64", "-9223372036854775808",
+			[]byte{0xc4, 0x82, 0x00, 0x3b, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}),
+	)
+
+	DescribeTable("UnmarshalCBOR other forms", func(b []byte, exp string) {
+		var d decimal.Decimal
+		Ω(d.UnmarshalCBOR(b)).Should(Succeed())
+		Ω(d.String()).Should(Equal(exp))
+	},
+		Entry("bignum mantissa", []byte{0xc4, 0x82, 0x21, 0xc2, 0x42, 0x6a, 0xb3}, "273.15"),
+		Entry("negative bignum mantissa", []byte{0xc4, 0x82, 0x21, 0xc3, 0x41, 0x00}, "-0.01"),
+		Entry("bignum leading zeros", []byte{0xc4, 0x82, 0x00, 0xc3, 0x49, 0x00, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			"-9223372036854775808"),
+		Entry("positive exponent", []byte{0xc4, 0x82, 0x02, 0x03}, "300"),
+		Entry("exponent beyond max scale", []byte{0xc4, 0x82, 0x33, 0x18, 0x64}, "0.000000000000000001"),
+		Entry("zero beyond max scale", []byte{0xc4, 0x82, 0x38, 0x63, 0x00}, "0.000000000000000000"),
+		Entry("unsigned", []byte{0x18, 0x2a}, "42"),
+		Entry("negative", []byte{0x38, 0x29}, "-42"),
+		Entry("text", []byte{0x64, '1', '.', '5', '0'}, "1.50"),
+	)
+
+	DescribeTable("UnmarshalCBOR error", func(b []byte, exp interface{}) {
+		var d decimal.Decimal
+		Ω(d.UnmarshalCBOR(b)).Should(MatchError(exp))
+	},
+		Entry("empty", []byte{}, "[decimal] invalid CBOR data "),
+		Entry("null", []byte{0xf6}, "[decimal] can not decode CBOR f6 to Decimal"),
+		Entry("float", []byte{0xf9, 0x3c, 0x00}, "[decimal] can not decode CBOR f93c00 to Decimal"),
+		Entry("other tag", []byte{0xc5, 0x82, 0x00, 0x00}, "[decimal] can not decode CBOR c5820000 to Decimal"),
+		Entry("trailing data", []byte{0x01, 0x02}, "[decimal] can not decode CBOR 0102 to Decimal"),
+		Entry("short text", []byte{0x64, '1'}, "[decimal] can not decode CBOR 6431 to Decimal"),
+		Entry("not array", []byte{0xc4, 0x00}, "[decimal] invalid CBOR decimal fraction c400"),
+		Entry("short array", []byte{0xc4, 0x81, 0x00}, "[decimal] invalid CBOR decimal fraction c48100"),
+		Entry("missing mantissa", []byte{0xc4, 0x82, 0x00}, "[decimal] invalid CBOR decimal fraction c48200"),
+		Entry("float mantissa", []byte{0xc4, 0x82, 0x00, 0xf9, 0x3c, 0x00}, "[decimal] invalid CBOR decimal fraction c48200f93c00"),
+		Entry("trailing mantissa", []byte{0xc4, 0x82, 0x00, 0x00, 0x00}, "[decimal] invalid CBOR decimal fraction c482000000"),
+		Entry("uint overflow", []byte{0x1b, 0x80, 0, 0, 0, 0, 0, 0, 0}, decimal.ErrOverflow),
+		Entry("negative overflow", []byte{0x3b, 0x80, 0, 0, 0, 0, 0, 0, 0}, decimal.ErrOverflow),
+		Entry("exponent overflow", []byte{0xc4, 0x82, 0x13, 0x01}, decimal.ErrOverflow),
+		Entry("bignum overflow", []byte{0xc4, 0x82, 0x00, 0xc2, 0x49, 0x01, 0, 0, 0, 0, 0, 0, 0, 0}, decimal.ErrOverflow),
+		Entry("precision loss", []byte{0xc4, 0x82, 0x33, 0x01}, decimal.ErrPrecisionLoss),
+	)
+
+	It("NullDecimal", func() {
+		Ω(decimal.NullDecimal{}.MarshalCBOR()).Should(Equal([]byte{0xf6}))
+		d := decimal.NullDecimal{Decimal: decimal.New(150, 2), Valid: true}
+		Ω(d.MarshalCBOR()).Should(Equal([]byte{0xc4, 0x82, 0x21, 0x18, 0x96}))
+
+		var back decimal.NullDecimal
+		Ω(back.UnmarshalCBOR([]byte{0xc4, 0x82, 0x21, 0x18, 0x96})).Should(Succeed())
+		Ω(back).Should(Equal(d))
+		Ω(back.UnmarshalCBOR([]byte{0xf6})).Should(Succeed())
+		Ω(back).Should(Equal(decimal.NullDecimal{}))
+		back.Valid = true
+		Ω(back.UnmarshalCBOR([]byte{0xf7})).Should(Succeed())
+		Ω(back.Valid).Should(BeFalse())
+		Ω(back.UnmarshalCBOR([]byte{0xf9, 0x3c, 0x00})).ShouldNot(Succeed())
+	})
+
+	Context("interop", func() {
+		type reading struct {
+			Value decimal.Decimal     `cbor:"value"`
+			Delta decimal.NullDecimal `cbor:"delta"`
+			Min   decimal.NullDecimal `cbor:"min"`
+		}
+
+		It("round trip", func() {
+			r := reading{
+				Value: decimal.New(-27315, 2),
+				Delta: decimal.NullDecimal{Decimal: decimal.New(1, 3), Valid: true},
+			}
+			b, err := cbor.Marshal(r)
+			Ω(err).Should(Succeed())
+			var back reading
+			Ω(cbor.Unmarshal(b, &back)).Should(Succeed())
+			Ω(back).Should(Equal(r))
+		})
+
+		It("decode as generic tag", func() {
+			b, err := cbor.Marshal(decimal.New(27315, 2))
+			Ω(err).Should(Succeed())
+			var v interface{}
+			Ω(cbor.Unmarshal(b, &v)).Should(Succeed())
+			Ω(v).Should(Equal(cbor.Tag{Number: 4, Content: []interface{}{int64(-2), uint64(27315)}}))
+		})
+
+		It("decode generic values", func() {
+			b, err := cbor.Marshal(map[string]interface{}{
+				"value": cbor.Tag{Number: 4, Content: []interface{}{-1, 15}},
+				"delta": "0.25",
+				"min":   nil,
+			})
+			Ω(err).Should(Succeed())
+			back := reading{Min: decimal.NullDecimal{Valid: true}}
+			Ω(cbor.Unmarshal(b, &back)).Should(Succeed())
+			Ω(back).Should(Equal(reading{
+				Value: decimal.New(15, 1),
+				Delta: decimal.NullDecimal{Decimal: decimal.New(25, 2), Valid: true},
+			}))
+		})
+	})
+})
@@ -0,0 +1,132 @@
+package columnar
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/redforks/math/decimal"
+)
+
+// ArrowType returns Arrow Decimal128 data type of t.
+func ArrowType(t Type) *arrow.Decimal128Type {
+	return &arrow.Decimal128Type{Precision: int32(t.Precision), Scale: int32(t.Scale)}
+}
+
+// ToArrow returns Arrow Decimal128 array of values rescaled to t, use
+// TypeOf(values) as t to keep all values exactly. Returns *ValueError if a
+// value loses precision or exceeds t.Precision. Caller should release the
+// array after use.
+func ToArrow(mem memory.Allocator, values []decimal.Decimal, t Type) (*array.Decimal128, error) {
+	if err := t.checkWrite(MaxPrecision); err != nil {
+		return nil, err
+	}
+
+	b := array.NewDecimal128Builder(mem, ArrowType(t))
+	defer b.Release()
+	b.Reserve(len(values))
+	for i, v := range values {
+		n, err := toNum(i, v, t)
+		if err != nil {
+			return nil, err
+		}
+		b.UnsafeAppend(n)
+	}
+	return b.NewDecimal128Array(), nil
+}
+
+// ToArrowNull is ToArrow() of nullable values, NULL values are null in the
+// array.
+func ToArrowNull(mem memory.Allocator, values []decimal.NullDecimal, t Type) (*array.Decimal128, error) {
+	if err := t.checkWrite(MaxPrecision); err != nil {
+		return nil, err
+	}
+
+	b := array.NewDecimal128Builder(mem, ArrowType(t))
+	defer b.Release()
+	b.Reserve(len(values))
+	for i, v := range values {
+		if !v.Valid {
+			b.AppendNull()
+			continue
+		}
+		n, err := toNum(i, v.Decimal, t)
+		if err != nil {
+			return nil, err
+		}
+		b.UnsafeAppend(n)
+	}
+	return b.NewDecimal128Array(), nil
+}
+
+// FromArrow converts Arrow Decimal128 array to values, scale of values is the
+// scale of the array type. Scale greater than decimal.MaxScale reduced if
+// digits beyond are zeros. Returns *ValueError if a value is null, loses
+// precision or out of Decimal range.
+func FromArrow(arr *array.Decimal128) ([]decimal.Decimal, error) {
+	scale, err := arrowScale(arr)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]decimal.Decimal, arr.Len())
+	for i := range r {
+		if arr.IsNull(i) {
+			return nil, &ValueError{i, "null", fmt.Errorf("[%s] null value", tag)}
+		}
+		if r[i], err = fromNum(i, arr.Value(i), scale); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// FromArrowNull is FromArrow() to nullable values, null values converted to
+// NULL.
+func FromArrowNull(arr *array.Decimal128) ([]decimal.NullDecimal, error) {
+	scale, err := arrowScale(arr)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]decimal.NullDecimal, arr.Len())
+	for i := range r {
+		if arr.IsNull(i) {
+			continue
+		}
+		if r[i].Decimal, err = fromNum(i, arr.Value(i), scale); err != nil {
+			return nil, err
+		}
+		r[i].Valid = true
+	}
+	return r, nil
+}
+
+func arrowScale(arr *array.Decimal128) (int, error) {
+	dt := arr.DataType().(*arrow.Decimal128Type)
+	t := Type{int(dt.Precision), int(dt.Scale)}
+	return t.Scale, t.check(MaxPrecision)
+}
+
+func toNum(i int, v decimal.Decimal, t Type) (decimal128.Num, error) {
+	u, b, err := unscaled(i, v, t)
+	switch {
+	case err != nil:
+		return decimal128.Num{}, err
+	case b != nil:
+		return decimal128.FromBigInt(b), nil
+	default:
+		return decimal128.FromI64(u), nil
+	}
+}
+
+func fromNum(i int, n decimal128.Num, scale int) (decimal.Decimal, error) {
+	hi, lo := n.HighBits(), n.LowBits()
+	if hi == 0 && lo <= math.MaxInt64 || hi == -1 && lo > math.MaxInt64 {
+		return fromUnscaled(i, int64(lo), nil, scale)
+	}
+	return fromUnscaled(i, 0, n.BigInt(), scale)
+}
@@ -0,0 +1,28 @@
+package columnar_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+
+	"testing"
+)
+
+func TestColumnar(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Columnar Suite")
+}
+
+// toDecimals parse decimal strings, panics if any not a number, usable in
+// table entries.
+func toDecimals(ss ...string) []decimal.Decimal {
+	r := make([]decimal.Decimal, len(ss))
+	for i, s := range ss {
+		d, err := decimal.FromString(s)
+		if err != nil {
+			panic(err)
+		}
+		r[i] = d
+	}
+	return r
+}
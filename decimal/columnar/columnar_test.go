@@ -0,0 +1,249 @@
+package columnar_test
+
+import (
+	"errors"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/memory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/math/decimal/columnar"
+)
+
+// ginkgoT adapts GinkgoT() to memory.TestingT.
+type ginkgoT struct {
+	GinkgoTInterface
+}
+
+func (ginkgoT) Helper() {}
+
+var _ = Describe("Columnar", func() {
+	strings := func(values []decimal.Decimal) []string {
+		r := make([]string, len(values))
+		for i, v := range values {
+			r[i] = v.String()
+		}
+		return r
+	}
+
+	DescribeTable("TypeOf", func(exp columnar.Type, ss ...string) {
+		Ω(columnar.TypeOf(toDecimals(ss...))).Should(Equal(exp))
+	},
+		Entry("empty", columnar.Type{1, 0}),
+		Entry("zero", columnar.Type{1, 0}, "0"),
+		Entry("zero with scale", columnar.Type{2, 2}, "0.00"),
+		Entry("mixed scales", columnar.Type{5, 3}, "1.5", "-12.25", "0.001"),
+		Entry("beyond int64", columnar.Type{21, 2}, "9223372036854775807", "0.01"),
+	)
+
+	It("Type String", func() {
+		Ω(columnar.Type{10, 2}.String()).Should(Equal("decimal(10, 2)"))
+	})
+
+	Context("Unify", func() {
+		It("mixed scales", func() {
+			Ω(columnar.Unify(toDecimals("1.5", "-2.25", "3"), columnar.Type{5, 2})).Should(Equal([]int64{150, -225, 300}))
+		})
+
+		It("reduce scale", func() {
+			Ω(columnar.Unify(toDecimals("1.500", "2"), columnar.Type{3, 1})).Should(Equal([]int64{15, 20}))
+		})
+
+		DescribeTable("error", func(values []decimal.Decimal, t columnar.Type, exp string, is error) {
+			_, err := columnar.Unify(values, t)
+			Ω(err).Should(MatchError(exp))
+			if is != nil {
+				Ω(errors.Is(err, is)).Should(BeTrue())
+			}
+		},
+			Entry("loses precision", toDecimals("1.5", "1.25"), columnar.Type{5, 1},
+				"[math-columnar] value 1 1.25: [decimal] loses precision", decimal.ErrPrecisionLoss),
+			Entry("exceeds precision", toDecimals("999.99", "1000.00"), columnar.Type{5, 2},
+				"[math-columnar] value 1 1000.00: [decimal] value out of range", decimal.ErrOverflow),
+			Entry("beyond int64", toDecimals("9223372036854775807"), columnar.Type{20, 1},
+				"[math-columnar] value 0 9223372036854775807: [decimal] value out of range", decimal.ErrOverflow),
+			Entry("invalid precision", nil, columnar.Type{0, 0}, "[math-columnar] invalid type decimal(0, 0)", nil),
+			Entry("precision too large", nil, columnar.Type{39, 0}, "[math-columnar] invalid type decimal(39, 0)", nil),
+			Entry("scale greater than precision", nil, columnar.Type{2, 3}, "[math-columnar] invalid type decimal(2, 3)", nil),
+			Entry("negative scale", nil, columnar.Type{2, -1}, "[math-columnar] invalid type decimal(2, -1)", nil),
+			Entry("scale out of range", nil, columnar.Type{20, 19}, "[math-columnar] scale of decimal(20, 19) out of range", nil),
+		)
+	})
+
+	Context("Arrow", func() {
+		var mem *memory.CheckedAllocator
+
+		BeforeEach(func() {
+			mem = memory.NewCheckedAllocator(memory.NewGoAllocator())
+		})
+
+		AfterEach(func() {
+			mem.AssertSize(ginkgoT{GinkgoT()}, 0)
+		})
+
+		It("round trip", func() {
+			values := toDecimals("1.5", "-12.25", "0.001", "0")
+			t := columnar.TypeOf(values)
+			arr, err := columnar.ToArrow(mem, values, t)
+			Ω(err).Should(Succeed())
+			defer arr.Release()
+
+			Ω(arr.DataType()).Should(Equal(&arrow.Decimal128Type{Precision: 5, Scale: 3}))
+			Ω(arr.Values()).Should(Equal([]decimal128.Num{
+				decimal128.FromI64(1500), decimal128.FromI64(-12250), decimal128.FromI64(1), {},
+			}))
+
+			back, err := columnar.FromArrow(arr)
+			Ω(err).Should(Succeed())
+			Ω(strings(back)).Should(Equal([]string{"1.500", "-12.250", "0.001", "0.000"}))
+		})
+
+		It("beyond int64", func() {
+			values := toDecimals("-9223372036854775808", "0.01")
+			arr, err := columnar.ToArrow(mem, values, columnar.TypeOf(values))
+			Ω(err).Should(Succeed())
+			defer arr.Release()
+			Ω(arr.Value(0).BigInt().String()).Should(Equal("-922337203685477580800"))
+
+			_, err = columnar.FromArrow(arr)
+			Ω(err).Should(MatchError("[math-columnar] value 0 -922337203685477580800e-2: [decimal] value out of range"))
+		})
+
+		It("loses precision", func() {
+			_, err := columnar.ToArrow(mem, toDecimals("1.5", "1.25"), columnar.Type{10, 1})
+			Ω(err).Should(MatchError("[math-columnar] value 1 1.25: [decimal] loses precision"))
+			_, err = columnar.ToArrow(mem, nil, columnar.Type{39, 1})
+			Ω(err).Should(MatchError("[math-columnar] invalid type decimal(39, 1)"))
+		})
+
+		It("NullDecimal", func() {
+			values := []decimal.NullDecimal{
+				{Decimal: decimal.New(15, 1), Valid: true},
+				{},
+				{Decimal: decimal.New(-1, 0), Valid: true},
+			}
+			arr, err := columnar.ToArrowNull(mem, values, columnar.Type{4, 2})
+			Ω(err).Should(Succeed())
+			defer arr.Release()
+			Ω(arr.NullN()).Should(Equal(1))
+			Ω(arr.IsNull(1)).Should(BeTrue())
+
+			back, err := columnar.FromArrowNull(arr)
+			Ω(err).Should(Succeed())
+			Ω(back).Should(Equal([]decimal.NullDecimal{
+				{Decimal: decimal.New(150, 2), Valid: true},
+				{},
+				{Decimal: decimal.New(-100, 2), Valid: true},
+			}))
+
+			_, err = columnar.FromArrow(arr)
+			Ω(err).Should(MatchError("[math-columnar] value 1 null: [math-columnar] null value"))
+
+			_, err = columnar.ToArrowNull(mem, values, columnar.Type{4, 0})
+			Ω(err).Should(MatchError("[math-columnar] value 0 1.5: [decimal] loses precision"))
+		})
+
+		It("scale beyond decimal.MaxScale", func() {
+			b := array.NewDecimal128Builder(mem, &arrow.Decimal128Type{Precision: 38, Scale: 20})
+			defer b.Release()
+			b.Append(decimal128.FromI64(-1500))
+			b.AppendNull()
+			b.Append(decimal128.FromI64(1))
+			arr := b.NewDecimal128Array()
+			defer arr.Release()
+
+			_, err := columnar.FromArrowNull(arr)
+			Ω(err).Should(MatchError("[math-columnar] value 2 1e-20: [decimal] loses precision"))
+
+			arr = array.NewSlice(arr, 0, 2).(*array.Decimal128)
+			defer arr.Release()
+			back, err := columnar.FromArrowNull(arr)
+			Ω(err).Should(Succeed())
+			Ω(back).Should(Equal([]decimal.NullDecimal{{Decimal: decimal.New(-15, 18), Valid: true}, {}}))
+		})
+	})
+
+	Context("Parquet", func() {
+		DescribeTable("FixedLen", func(precision, exp int) {
+			Ω(columnar.FixedLen(precision)).Should(Equal(exp))
+		},
+			Entry("1", 1, 1),
+			Entry("2", 2, 1),
+			Entry("3", 3, 2),
+			Entry("9", 9, 4),
+			Entry("10", 10, 5),
+			Entry("18", 18, 8),
+			Entry("19", 19, 9),
+			Entry("38", 38, 16),
+		)
+
+		It("INT32", func() {
+			values := toDecimals("1.5", "-0.25", "9999999.99")
+			t := columnar.Type{9, 2}
+			Ω(columnar.ToParquetInt32(values, t)).Should(Equal([]int32{150, -25, 999999999}))
+
+			back, err := columnar.FromParquetInt32([]int32{150, -25, 999999999}, t)
+			Ω(err).Should(Succeed())
+			Ω(strings(back)).Should(Equal([]string{"1.50", "-0.25", "9999999.99"}))
+
+			_, err = columnar.ToParquetInt32(values, columnar.Type{10, 2})
+			Ω(err).Should(MatchError("[math-columnar] invalid type decimal(10, 2)"))
+			_, err = columnar.ToParquetInt32(toDecimals("10000000"), t)
+			Ω(err).Should(MatchError("[math-columnar] value 0 10000000: [decimal] value out of range"))
+			_, err = columnar.FromParquetInt32(nil, columnar.Type{10, 2})
+			Ω(err).Should(MatchError("[math-columnar] invalid type decimal(10, 2)"))
+		})
+
+		It("INT64", func() {
+			values := toDecimals("1.5", "-0.125")
+			t := columnar.Type{18, 3}
+			Ω(columnar.ToParquetInt64(values, t)).Should(Equal([]int64{1500, -125}))
+
+			back, err := columnar.FromParquetInt64([]int64{1500, -125}, t)
+			Ω(err).Should(Succeed())
+			Ω(strings(back)).Should(Equal([]string{"1.500", "-0.125"}))
+
+			_, err = columnar.ToParquetInt64(values, columnar.Type{19, 3})
+			Ω(err).Should(MatchError("[math-columnar] invalid type decimal(19, 3)"))
+			_, err = columnar.ToParquetInt64(values, columnar.Type{18, 2})
+			Ω(err).Should(MatchError("[math-columnar] value 1 -0.125: [decimal] loses precision"))
+		})
+
+		It("FIXED_LEN_BYTE_ARRAY", func() {
+			values := toDecimals("1.5", "-0.01", "0", "-9223372036854775808")
+			t := columnar.Type{22, 2}
+			fixed, err := columnar.ToParquetFixed(values, t)
+			Ω(err).Should(Succeed())
+			Ω(fixed).Should(Equal([][]byte{
+				{0, 0, 0, 0, 0, 0, 0, 0, 0, 0x96},
+				{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0xff, 0xce, 0, 0, 0, 0, 0, 0, 0, 0},
+			}))
+
+			back, err := columnar.FromParquetFixed(fixed[:3], t)
+			Ω(err).Should(Succeed())
+			Ω(strings(back)).Should(Equal([]string{"1.50", "-0.01", "0.00"}))
+
+			_, err = columnar.FromParquetFixed(fixed, t)
+			Ω(err).Should(MatchError("[math-columnar] value 3 -922337203685477580800e-2: [decimal] value out of range"))
+		})
+
+		It("FromParquetFixed short values", func() {
+			back, err := columnar.FromParquetFixed([][]byte{
+				{0x80},
+				{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+				{0xff, 0x80, 0, 0, 0, 0, 0, 0, 0},
+			}, columnar.Type{19, 0})
+			Ω(err).Should(Succeed())
+			Ω(strings(back)).Should(Equal([]string{"-128", "9223372036854775807", "-9223372036854775808"}))
+
+			_, err = columnar.FromParquetFixed([][]byte{{1}, {}}, columnar.Type{19, 0})
+			Ω(err).Should(MatchError("[math-columnar] value 1 : [math-columnar] empty value"))
+		})
+	})
+})
@@ -0,0 +1,167 @@
+package columnar
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/redforks/math/decimal"
+)
+
+// Max precision of Parquet DECIMAL stored as INT32 and INT64.
+const (
+	MaxInt32Precision = 9
+	MaxInt64Precision = 18
+)
+
+// FixedLen returns the minimal byte length of FIXED_LEN_BYTE_ARRAY stores
+// DECIMAL of specific precision, such as 5 for precision 10, 16 for 38.
+func FixedLen(precision int) int {
+	// n bytes stores 10^precision - 1 if (8n - 1) bits holds it.
+	bits := int(math.Ceil(float64(precision) * math.Log2(10)))
+	return bits/8 + 1
+}
+
+// ToParquetInt32 returns unscaled values rescaled to t, stored as Parquet
+// INT32. t.Precision must not exceed MaxInt32Precision.
+func ToParquetInt32(values []decimal.Decimal, t Type) ([]int32, error) {
+	if err := t.checkWrite(MaxInt32Precision); err != nil {
+		return nil, err
+	}
+
+	r := make([]int32, len(values))
+	for i, v := range values {
+		u, _, err := unscaled(i, v, t)
+		if err != nil {
+			return nil, err
+		}
+		r[i] = int32(u)
+	}
+	return r, nil
+}
+
+// ToParquetInt64 returns unscaled values rescaled to t, stored as Parquet
+// INT64. t.Precision must not exceed MaxInt64Precision.
+func ToParquetInt64(values []decimal.Decimal, t Type) ([]int64, error) {
+	if err := t.checkWrite(MaxInt64Precision); err != nil {
+		return nil, err
+	}
+	return Unify(values, t)
+}
+
+// ToParquetFixed returns unscaled values rescaled to t, stored as Parquet
+// FIXED_LEN_BYTE_ARRAY of FixedLen(t.Precision) bytes, big endian two's
+// complement.
+func ToParquetFixed(values []decimal.Decimal, t Type) ([][]byte, error) {
+	if err := t.checkWrite(MaxPrecision); err != nil {
+		return nil, err
+	}
+
+	n := FixedLen(t.Precision)
+	buf := make([]byte, n*len(values))
+	r := make([][]byte, len(values))
+	for i, v := range values {
+		u, b, err := unscaled(i, v, t)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			b = big.NewInt(u)
+		}
+		r[i] = buf[i*n : (i+1)*n : (i+1)*n]
+		putTwosComplement(r[i], b)
+	}
+	return r, nil
+}
+
+// FromParquetInt32 converts unscaled values of Parquet DECIMAL stored as
+// INT32 to values of t.Scale.
+func FromParquetInt32(values []int32, t Type) ([]decimal.Decimal, error) {
+	if err := t.check(MaxInt32Precision); err != nil {
+		return nil, err
+	}
+
+	r := make([]decimal.Decimal, len(values))
+	for i, v := range values {
+		var err error
+		if r[i], err = fromUnscaled(i, int64(v), nil, t.Scale); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// FromParquetInt64 converts unscaled values of Parquet DECIMAL stored as
+// INT64 to values of t.Scale.
+func FromParquetInt64(values []int64, t Type) ([]decimal.Decimal, error) {
+	if err := t.check(MaxInt64Precision); err != nil {
+		return nil, err
+	}
+
+	r := make([]decimal.Decimal, len(values))
+	for i, v := range values {
+		var err error
+		if r[i], err = fromUnscaled(i, v, nil, t.Scale); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// FromParquetFixed converts unscaled values of Parquet DECIMAL stored as
+// FIXED_LEN_BYTE_ARRAY, or BYTE_ARRAY, to values of t.Scale. Returns
+// *ValueError if a value is empty, loses precision or out of Decimal range.
+func FromParquetFixed(values [][]byte, t Type) ([]decimal.Decimal, error) {
+	if err := t.check(MaxPrecision); err != nil {
+		return nil, err
+	}
+
+	r := make([]decimal.Decimal, len(values))
+	for i, v := range values {
+		if len(v) == 0 {
+			return nil, &ValueError{i, "", fmt.Errorf("[%s] empty value", tag)}
+		}
+
+		var err error
+		if len(v) <= 8 {
+			// sign extend to int64
+			u := int64(int8(v[0]))
+			for _, c := range v[1:] {
+				u = u<<8 | int64(c)
+			}
+			r[i], err = fromUnscaled(i, u, nil, t.Scale)
+		} else {
+			r[i], err = fromUnscaled(i, 0, twosComplement(v), t.Scale)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// putTwosComplement puts b into buf as big endian two's complement, b must
+// fit in len(buf) bytes.
+func putTwosComplement(buf []byte, b *big.Int) {
+	if b.Sign() < 0 {
+		// two's complement of negative b is 2^(8n) + b
+		m := new(big.Int).Lsh(big.NewInt(1), uint(8*len(buf)))
+		b = m.Add(m, b)
+	}
+
+	bytes := b.Bytes()
+	for i := range buf[:len(buf)-len(bytes)] {
+		buf[i] = 0
+	}
+	copy(buf[len(buf)-len(bytes):], bytes)
+}
+
+// twosComplement returns value of big endian two's complement buf.
+func twosComplement(buf []byte) *big.Int {
+	b := new(big.Int).SetBytes(buf)
+	if buf[0]&0x80 != 0 {
+		m := new(big.Int).Lsh(big.NewInt(1), uint(8*len(buf)))
+		b.Sub(b, m)
+	}
+	return b
+}
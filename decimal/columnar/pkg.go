@@ -0,0 +1,11 @@
+// Package columnar converts decimal.Decimal columns to and from decimal types
+// of columnar formats: Apache Arrow Decimal128 arrays, and Parquet DECIMAL
+// logical type stored as INT32, INT64 or FIXED_LEN_BYTE_ARRAY.
+//
+// Columnar formats store unscaled values of the same precision and scale for a
+// column, values of different scales are rescaled to the scale of the column.
+// Values losing precision or exceeding precision of the column are reported
+// as *ValueError, never rounded.
+package columnar
+
+const tag = "math-columnar"
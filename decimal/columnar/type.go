@@ -0,0 +1,167 @@
+package columnar
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/redforks/math/decimal"
+)
+
+// MaxPrecision is the max precision of Arrow Decimal128 and Parquet DECIMAL
+// stored in 16 bytes.
+const MaxPrecision = 38
+
+// Type is the decimal type of a column, values have at most Precision digits,
+// Scale of them after decimal point. Scale must be in [0, Precision].
+type Type struct {
+	Precision, Scale int
+}
+
+// TypeOf returns the smallest Type holds all values without losing precision,
+// Scale is the max scale of values. Returns Type{1, 0} for empty values.
+func TypeOf(values []decimal.Decimal) Type {
+	t := Type{1, 0}
+	for _, v := range values {
+		if s := int(v.Scale()); s > t.Scale {
+			t.Scale = s
+		}
+	}
+	for _, v := range values {
+		if p := precision(v, t.Scale); p > t.Precision {
+			t.Precision = p
+		}
+	}
+	if t.Precision < t.Scale {
+		t.Precision = t.Scale
+	}
+	return t
+}
+
+func (t Type) String() string {
+	return fmt.Sprintf("decimal(%d, %d)", t.Precision, t.Scale)
+}
+
+// check returns error if t invalid or its precision exceeds max.
+func (t Type) check(max int) error {
+	if t.Precision < 1 || t.Precision > max || t.Scale < 0 || t.Scale > t.Precision {
+		return fmt.Errorf("[%s] invalid type %s", tag, t)
+	}
+	return nil
+}
+
+// checkWrite is check() and also requires scale not exceed decimal.MaxScale.
+func (t Type) checkWrite(max int) error {
+	if err := t.check(max); err != nil {
+		return err
+	}
+	if t.Scale > decimal.MaxScale {
+		return fmt.Errorf("[%s] scale of %s out of range", tag, t)
+	}
+	return nil
+}
+
+// ValueError records a value can not be converted, such as loses precision
+// when rescaled to the scale of the column.
+type ValueError struct {
+	Index int    // Index of the value in the column
+	Value string // Value in string, unscaled values such as "12345e-20"
+	Err   error  // decimal.ErrPrecisionLoss or decimal.ErrOverflow
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("[%s] value %d %s: %s", tag, e.Index, e.Value, e.Err)
+}
+
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+// Unify returns unscaled values of values rescaled to t.Scale, such as 1.5 and
+// 2.25 to scale 2 are 150 and 225. Returns *ValueError of the first value
+// loses precision, or exceeds t.Precision or int64 range.
+func Unify(values []decimal.Decimal, t Type) ([]int64, error) {
+	if err := t.checkWrite(MaxPrecision); err != nil {
+		return nil, err
+	}
+
+	r := make([]int64, len(values))
+	for i, v := range values {
+		u, b, err := unscaled(i, v, t)
+		if err != nil {
+			return nil, err
+		}
+		if b != nil {
+			return nil, &ValueError{i, v.String(), decimal.ErrOverflow}
+		}
+		r[i] = u
+	}
+	return r, nil
+}
+
+// precision returns number of digits of v rescaled to scale, scale must not
+// less than scale of v.
+func precision(v decimal.Decimal, scale int) int {
+	if v.Sign() == 0 {
+		return 1
+	}
+	return v.Precision() + scale - int(v.Scale())
+}
+
+// unscaled returns unscaled value of v rescaled to t.Scale, b is non-nil if
+// out of int64 range. Returns *ValueError of index i if v loses precision or
+// exceeds t.Precision.
+func unscaled(i int, v decimal.Decimal, t Type) (u int64, b *big.Int, err error) {
+	r, err := v.Rescale(t.Scale)
+	switch err {
+	case nil:
+		if precision(r, t.Scale) > t.Precision {
+			return 0, nil, &ValueError{i, v.String(), decimal.ErrOverflow}
+		}
+		return r.Coefficient(), nil, nil
+	case decimal.ErrOverflow:
+		if precision(v, t.Scale) > t.Precision {
+			return 0, nil, &ValueError{i, v.String(), decimal.ErrOverflow}
+		}
+		b = big.NewInt(v.Coefficient())
+		return 0, b.Mul(b, pow10(t.Scale-int(v.Scale()))), nil
+	default:
+		return 0, nil, &ValueError{i, v.String(), err}
+	}
+}
+
+// fromUnscaled returns Decimal of u * 10^-scale. If b is not nil, it is the
+// unscaled value instead of u. Scale greater than decimal.MaxScale reduced by
+// stripping trailing zeros. Returns *ValueError of index i if the value loses
+// precision or out of range.
+func fromUnscaled(i int, u int64, b *big.Int, scale int) (decimal.Decimal, error) {
+	if b == nil && scale <= decimal.MaxScale {
+		return decimal.New(u, scale), nil
+	}
+
+	if b == nil {
+		b = big.NewInt(u)
+	} else {
+		b = new(big.Int).Set(b)
+	}
+	value := fmt.Sprintf("%se-%d", b, scale)
+
+	if scale > decimal.MaxScale && b.Sign() != 0 {
+		var q, r big.Int
+		p := pow10(scale - decimal.MaxScale)
+		if q.QuoRem(b, p, &r); r.Sign() != 0 {
+			return decimal.Decimal{}, &ValueError{i, value, decimal.ErrPrecisionLoss}
+		}
+		b = &q
+	}
+	if scale > decimal.MaxScale {
+		scale = decimal.MaxScale
+	}
+	if !b.IsInt64() {
+		return decimal.Decimal{}, &ValueError{i, value, decimal.ErrOverflow}
+	}
+	return decimal.New(b.Int64(), scale), nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,157 @@
+package csvio_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/math/decimal/csvio"
+)
+
+type entry struct {
+	Date    string              `csv:"Date"`
+	Amount  decimal.Decimal     `csv:"Amount"`
+	Fee     decimal.NullDecimal `csv:"Fee"`
+	Ignored decimal.Decimal
+	Skipped string `csv:"-"`
+}
+
+var _ = Describe("Reader and Writer", func() {
+	newReader := func(s string) *csvio.Reader {
+		r := csv.NewReader(strings.NewReader(s))
+		r.Comma = ';'
+		return csvio.NewReader(r)
+	}
+
+	It("Read", func() {
+		r := newReader(`Fee;Memo;Date;Amount
+1,50;x;2020-01-02;1.234,56-
+;y;2020-01-03;  12,00
+`)
+		r.Format = csvio.Format{DecimalMark: ',', ThousandsSep: '.', Sign: csvio.TrailingMinus}
+		r.Columns = map[string]csvio.Format{"Fee": {DecimalMark: ',', Scale: 2}}
+
+		var e entry
+		Ω(r.Read(&e)).Should(Succeed())
+		Ω(e).Should(Equal(entry{
+			Date:   "2020-01-02",
+			Amount: toDecimal("-1234.56"),
+			Fee:    decimal.NullDecimal{Decimal: toDecimal("1.50"), Valid: true},
+		}))
+		Ω(r.Header()).Should(Equal([]string{"Fee", "Memo", "Date", "Amount"}))
+		Ω(r.Record()).Should(Equal([]string{"1,50", "x", "2020-01-02", "1.234,56-"}))
+
+		Ω(r.Read(&e)).Should(Succeed())
+		Ω(e).Should(Equal(entry{
+			Date:   "2020-01-03",
+			Amount: toDecimal("12.00"),
+		}))
+		Ω(r.Record()[1]).Should(Equal("y"))
+
+		Ω(r.Read(&e)).Should(Equal(io.EOF))
+	})
+
+	It("Read error with row and column", func() {
+		r := newReader(`Date;Amount;Fee
+2020-01-02;1.5;
+2020-01-03;1.5x;
+`)
+		var e entry
+		Ω(r.Read(&e)).Should(Succeed())
+		err := r.Read(&e)
+		Ω(err).Should(MatchError(`[math-csvio] row 3 column "Amount": [decimal] "1.5x" not a number, unexpected 'x' at position 3`))
+		var ce *csvio.Error
+		Ω(errors.As(err, &ce)).Should(BeTrue())
+		Ω(ce.Row).Should(Equal(3))
+		Ω(ce.Column).Should(Equal("Amount"))
+		Ω(errors.Is(err, decimal.ErrSyntax)).Should(BeTrue())
+	})
+
+	It("Read NULL to Decimal", func() {
+		r := newReader("Date;Amount;Fee\n2020-01-02; ;1\n")
+		err := r.Read(&entry{})
+		Ω(err).Should(MatchError(`[math-csvio] row 2 column "Amount": [math-csvio] NULL value to Decimal field`))
+		Ω(errors.Is(err, csvio.ErrNull)).Should(BeTrue())
+	})
+
+	It("Read missing column", func() {
+		r := newReader("Date;Fee\n2020-01-02;1\n")
+		Ω(r.Read(&entry{})).Should(MatchError(`[math-csvio] missing column "Amount" in CSV header`))
+	})
+
+	It("Read bad argument", func() {
+		r := newReader("Date;Amount;Fee\n")
+		Ω(r.Read(entry{})).Should(MatchError("[math-csvio] expect pointer to struct, got csvio_test.entry"))
+		var i int
+		Ω(r.Read(&i)).Should(MatchError("[math-csvio] expect struct or pointer to struct, got *int"))
+		Ω(r.Read(&struct {
+			V int `csv:"V"`
+		}{})).Should(MatchError("[math-csvio] unsupported type int of field V"))
+		Ω(r.Read(&struct {
+			v string `csv:"V"`
+		}{})).Should(MatchError("[math-csvio] field v not exported"))
+	})
+
+	It("Read empty file", func() {
+		r := newReader("")
+		Ω(r.Read(&entry{})).Should(Equal(io.EOF))
+	})
+
+	It("Write", func() {
+		var buf bytes.Buffer
+		cw := csv.NewWriter(&buf)
+		cw.Comma = ';'
+		w := csvio.NewWriter(cw)
+		w.Format = csvio.Format{ThousandsSep: ',', Sign: csvio.Parentheses, Scale: 2}
+		w.Columns = map[string]csvio.Format{"Fee": {DecimalMark: ','}}
+
+		Ω(w.Write(entry{
+			Date:   "2020-01-02",
+			Amount: toDecimal("-1234.5"),
+			Fee:    decimal.NullDecimal{Decimal: toDecimal("1.5"), Valid: true},
+		})).Should(Succeed())
+		Ω(w.Write(&entry{Date: "2020-01-03", Amount: toDecimal("12")})).Should(Succeed())
+		Ω(w.Flush()).Should(Succeed())
+		Ω(buf.String()).Should(Equal(`Date;Amount;Fee
+2020-01-02;(1,234.50);1,5
+2020-01-03;12.00;
+`))
+
+		Ω(w.Write(1)).Should(MatchError("[math-csvio] expect struct or pointer to struct, got int"))
+	})
+
+	It("round trip", func() {
+		f := csvio.Format{DecimalMark: ',', ThousandsSep: '.'}
+		entries := []entry{
+			{Date: "a", Amount: toDecimal("-1234567.891")},
+			{Date: "b", Amount: toDecimal("0.00"), Fee: decimal.NullDecimal{Decimal: toDecimal("-3"), Valid: true}},
+		}
+
+		var buf bytes.Buffer
+		w := csvio.NewWriter(csv.NewWriter(&buf))
+		w.Format = f
+		for _, e := range entries {
+			Ω(w.Write(e)).Should(Succeed())
+		}
+		Ω(w.Flush()).Should(Succeed())
+
+		r := csvio.NewReader(csv.NewReader(&buf))
+		r.Format = f
+		var back []entry
+		for {
+			var e entry
+			err := r.Read(&e)
+			if err == io.EOF {
+				break
+			}
+			Ω(err).Should(Succeed())
+			back = append(back, e)
+		}
+		Ω(back).Should(Equal(entries))
+	})
+})
@@ -0,0 +1,113 @@
+package csvio
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/redforks/math/decimal"
+)
+
+// Error records a failed conversion of a CSV cell.
+type Error struct {
+	Row    int    // 1-based row number, header is row 1
+	Column string // column name
+	Err    error  // the reason conversion failed
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("[%s] row %d column %q: %s", tag, e.Row, e.Column, e.Err)
+}
+
+// Unwrap returns the reason conversion failed.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrNull indicates that an empty cell read into a decimal.Decimal field, use
+// decimal.NullDecimal for nullable columns.
+var ErrNull = fmt.Errorf("[%s] NULL value to Decimal field", tag)
+
+type kind int
+
+const (
+	kindDecimal kind = iota
+	kindNullDecimal
+	kindString
+)
+
+var (
+	decimalType     = reflect.TypeOf(decimal.Decimal{})
+	nullDecimalType = reflect.TypeOf(decimal.NullDecimal{})
+	stringType      = reflect.TypeOf("")
+)
+
+// field is a struct field mapped to a CSV column.
+type field struct {
+	index  int // field index in struct
+	column string
+	kind   kind
+}
+
+// structFields returns fields of struct t mapped to CSV columns.
+func structFields(t reflect.Type) ([]field, error) {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		column, ok := f.Tag.Lookup("csv")
+		if !ok || column == "-" {
+			continue
+		}
+		if f.PkgPath != "" {
+			return nil, fmt.Errorf("[%s] field %s not exported", tag, f.Name)
+		}
+
+		var k kind
+		switch f.Type {
+		case decimalType:
+			k = kindDecimal
+		case nullDecimalType:
+			k = kindNullDecimal
+		case stringType:
+			k = kindString
+		default:
+			return nil, fmt.Errorf("[%s] unsupported type %s of field %s", tag, f.Type, f.Name)
+		}
+		fields = append(fields, field{i, column, k})
+	}
+	return fields, nil
+}
+
+// fieldCache caches mapped fields of struct types.
+type fieldCache map[reflect.Type][]field
+
+// structOf returns struct value v points to and its mapped fields.
+func (c *fieldCache) structOf(v interface{}) (reflect.Value, []field, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, nil, fmt.Errorf("[%s] expect struct or pointer to struct, got %T", tag, v)
+	}
+
+	fields, ok := (*c)[rv.Type()]
+	if !ok {
+		var err error
+		if fields, err = structFields(rv.Type()); err != nil {
+			return reflect.Value{}, nil, err
+		}
+		if *c == nil {
+			*c = make(fieldCache)
+		}
+		(*c)[rv.Type()] = fields
+	}
+	return rv, fields, nil
+}
+
+// formatOf returns Format of column, columns[column] if exist, otherwise def.
+func formatOf(def Format, columns map[string]Format, column string) Format {
+	if f, ok := columns[column]; ok {
+		return f
+	}
+	return def
+}
@@ -0,0 +1,200 @@
+package csvio
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/redforks/math/decimal"
+)
+
+// SignStyle is how negative values written in CSV.
+type SignStyle int
+
+const (
+	// MinusSign prefix negative values with minus sign, such as "-1.50".
+	MinusSign SignStyle = iota
+
+	// TrailingMinus suffix negative values with minus sign, such as "1.50-".
+	TrailingMinus
+
+	// Parentheses enclose negative values in parentheses, such as "(1.50)".
+	Parentheses
+)
+
+// Format is the text form of decimal values in CSV cells. Zero value is the
+// format of decimal.FromString() and Decimal.String().
+type Format struct {
+	// DecimalMark separates integer and fraction part, '.' if zero.
+	DecimalMark rune
+
+	// ThousandsSep groups integer digits such as ',' in "1,234.56", not
+	// grouped if zero. Parse accepts any grouping, such as "1,00,000".
+	ThousandsSep rune
+
+	// Sign is the sign convention of negative values. Parse also accepts
+	// leading sign regardless of Sign.
+	Sign SignStyle
+
+	// Scale is the min scale of values, passed to
+	// decimal.FromStringWithScale() when parsing, values formatted padding to
+	// it.
+	Scale int
+
+	// NullValues are cells read as NULL besides empty cell, such as "-" or
+	// "N/A".
+	NullValues []string
+}
+
+func (f Format) decimalMark() rune {
+	if f.DecimalMark == 0 {
+		return '.'
+	}
+	return f.DecimalMark
+}
+
+func (f Format) check() error {
+	if f.decimalMark() == f.ThousandsSep {
+		return fmt.Errorf("[%s] decimal mark and thousands separator are both %q", tag, f.ThousandsSep)
+	}
+	return nil
+}
+
+// Parse parses cell in format f, leading and trailing spaces ignored. Empty
+// cell or one of f.NullValues is NULL. Returns *decimal.ParseError of s if
+// not a number.
+func (f Format) Parse(s string) (decimal.NullDecimal, error) {
+	if err := f.check(); err != nil {
+		return decimal.NullDecimal{}, err
+	}
+
+	start, end := 0, len(s)
+	for start < end && isSpace(s[start]) {
+		start++
+	}
+	for end > start && isSpace(s[end-1]) {
+		end--
+	}
+	if start == end {
+		return decimal.NullDecimal{}, nil
+	}
+	for _, null := range f.NullValues {
+		if s[start:end] == null {
+			return decimal.NullDecimal{}, nil
+		}
+	}
+
+	neg := false
+	switch f.Sign {
+	case TrailingMinus:
+		if s[end-1] == '-' {
+			neg, end = true, end-1
+		}
+	case Parentheses:
+		if s[start] == '(' && s[end-1] == ')' {
+			neg, start, end = true, start+1, end-1
+		}
+	}
+
+	// normalize to format of decimal.FromString(), pos records offset of
+	// each byte in s, to report error in s.
+	mark := f.decimalMark()
+	norm := make([]byte, 0, end-start+1)
+	pos := make([]int, 0, end-start+1)
+	if neg {
+		if start < end && (s[start] == '-' || s[start] == '+') {
+			return decimal.NullDecimal{}, &decimal.ParseError{Str: s, Pos: start, Err: decimal.ErrSyntax}
+		}
+		norm, pos = append(norm, '-'), append(pos, start)
+	}
+	dot := false
+	for i := start; i < end; {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == f.ThousandsSep && r != 0:
+			// must between digits of integer part
+			if dot || i == start || !isDigit(s[i-1]) || i+n >= end || !isDigit(s[i+n]) {
+				return decimal.NullDecimal{}, &decimal.ParseError{Str: s, Pos: i, Err: decimal.ErrSyntax}
+			}
+		case r == mark:
+			dot = true
+			norm, pos = append(norm, '.'), append(pos, i)
+		case r == '.':
+			// '.' not the decimal mark
+			return decimal.NullDecimal{}, &decimal.ParseError{Str: s, Pos: i, Err: decimal.ErrSyntax}
+		default:
+			for j := i; j < i+n; j++ {
+				norm, pos = append(norm, s[j]), append(pos, j)
+			}
+		}
+		i += n
+	}
+
+	d, err := decimal.FromStringWithScale(string(norm), f.Scale)
+	if pe, ok := err.(*decimal.ParseError); ok {
+		p := end
+		if pe.Pos < len(pos) {
+			p = pos[pe.Pos]
+		}
+		return decimal.NullDecimal{}, &decimal.ParseError{Str: s, Pos: p, Err: pe.Err}
+	}
+	if err != nil {
+		return decimal.NullDecimal{}, err
+	}
+	return decimal.NullDecimal{Decimal: d, Valid: true}, nil
+}
+
+// Format returns d in format f, such as "(1,234.50)" of -1234.5 if
+// f.ThousandsSep is ',', f.Sign is Parentheses and f.Scale is 2.
+func (f Format) Format(d decimal.Decimal) string {
+	if r, err := d.Rescale(f.Scale); err == nil && int(d.Scale()) < f.Scale {
+		d = r
+	}
+
+	s := d.String()
+	neg := s[0] == '-'
+	if neg {
+		s = s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+
+	var b strings.Builder
+	switch {
+	case neg && f.Sign == MinusSign:
+		b.WriteByte('-')
+	case neg && f.Sign == Parentheses:
+		b.WriteByte('(')
+	}
+	for i := range intPart {
+		if f.ThousandsSep != 0 && i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteRune(f.ThousandsSep)
+		}
+		b.WriteByte(intPart[i])
+	}
+	if frac != "" {
+		b.WriteRune(f.decimalMark())
+		b.WriteString(frac)
+	}
+	switch {
+	case neg && f.Sign == TrailingMinus:
+		b.WriteByte('-')
+	case neg && f.Sign == Parentheses:
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+	return false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,90 @@
+package csvio_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/math/decimal/csvio"
+)
+
+var _ = Describe("Format", func() {
+	var (
+		plain    = csvio.Format{}
+		us       = csvio.Format{ThousandsSep: ',', Sign: csvio.Parentheses, Scale: 2}
+		de       = csvio.Format{DecimalMark: ',', ThousandsSep: '.', Sign: csvio.TrailingMinus}
+		ch       = csvio.Format{ThousandsSep: '\'', NullValues: []string{"-", "N/A"}}
+		fr       = csvio.Format{DecimalMark: ',', ThousandsSep: ' '}
+		badSepar = csvio.Format{ThousandsSep: '.'}
+	)
+
+	DescribeTable("Parse", func(f csvio.Format, s string, exp string) {
+		d, err := f.Parse(s)
+		Ω(err).Should(Succeed())
+		Ω(d.Valid).Should(BeTrue())
+		Ω(d.Decimal.String()).Should(Equal(exp))
+	},
+		Entry("plain", plain, "-1234.5", "-1234.5"),
+		Entry("plain spaces", plain, " 1.5\t", "1.5"),
+		Entry("plain exponent", plain, "1.5e2", "150"),
+		Entry("us", us, "1,234.5", "1234.50"),
+		Entry("us negative", us, "(1,234,567.891)", "-1234567.891"),
+		Entry("us leading minus", us, "-1,234", "-1234.00"),
+		Entry("us lakh grouping", us, "1,00,000", "100000.00"),
+		Entry("de", de, "1.234,56", "1234.56"),
+		Entry("de negative", de, "1.234,56-", "-1234.56"),
+		Entry("de leading minus", de, "-0,5", "-0.5"),
+		Entry("ch", ch, "1'234.5", "1234.5"),
+		Entry("fr", fr, "1 234,5", "1234.5"),
+	)
+
+	DescribeTable("Parse NULL", func(f csvio.Format, s string) {
+		Ω(f.Parse(s)).Should(Equal(decimal.NullDecimal{}))
+	},
+		Entry("empty", plain, ""),
+		Entry("spaces", plain, "  "),
+		Entry("null values", ch, "N/A"),
+		Entry("null values spaces", ch, " - "),
+	)
+
+	DescribeTable("Parse error", func(f csvio.Format, s string, exp string) {
+		_, err := f.Parse(s)
+		Ω(err).Should(MatchError(exp))
+	},
+		Entry("not a number", plain, "1.5x", `[decimal] "1.5x" not a number, unexpected 'x' at position 3`),
+		Entry("unexpected end", us, "(1,234.", `[decimal] "(1,234." not a number, unexpected '(' at position 0`),
+		Entry("separator at start", us, ",123", `[decimal] ",123" not a number, unexpected ',' at position 0`),
+		Entry("separator at end", us, "123,", `[decimal] "123," not a number, unexpected ',' at position 3`),
+		Entry("separator in fraction", us, "1.234,5", `[decimal] "1.234,5" not a number, unexpected ',' at position 5`),
+		Entry("double separator", us, "1,,234", `[decimal] "1,,234" not a number, unexpected ',' at position 1`),
+		Entry("dot not decimal mark", de, "1,5.0", `[decimal] "1,5.0" not a number, unexpected '.' at position 3`),
+		Entry("dot without separator", fr, "1.5", `[decimal] "1.5" not a number, unexpected '.' at position 1`),
+		Entry("double sign", de, "-1-", `[decimal] "-1-" not a number, unexpected '-' at position 0`),
+		Entry("sign in parentheses", us, "(-1)", `[decimal] "(-1)" not a number, unexpected '-' at position 1`),
+		Entry("error position mapped", de, " 1.234,5x", `[decimal] " 1.234,5x" not a number, unexpected 'x' at position 8`),
+		Entry("range", plain, "99999999999999999999", `[decimal] "99999999999999999999" effective number out of range at position 18`),
+		Entry("same marks", badSepar, "1", "[math-csvio] decimal mark and thousands separator are both '.'"),
+	)
+
+	DescribeTable("Format", func(f csvio.Format, s string, exp string) {
+		d, err := decimal.FromString(s)
+		Ω(err).Should(Succeed())
+		Ω(f.Format(d)).Should(Equal(exp))
+
+		back, err := f.Parse(exp)
+		Ω(err).Should(Succeed())
+		Ω(back.Decimal.Cmp(d)).Should(Equal(0))
+	},
+		Entry("plain", plain, "-1234.5", "-1234.5"),
+		Entry("us", us, "1234567.891", "1,234,567.891"),
+		Entry("us pad scale", us, "-1234", "(1,234.00)"),
+		Entry("us small", us, "-0.5", "(0.50)"),
+		Entry("us hundreds", us, "123", "123.00"),
+		Entry("de", de, "-1234.56", "1.234,56-"),
+		Entry("de integer", de, "1000", "1.000"),
+		Entry("ch", ch, "1234567", "1'234'567"),
+		Entry("fr", fr, "-1234.5", "-1 234,5"),
+		Entry("zero", us, "-0.000", "0.000"),
+		Entry("min int64", ch, "-9223372036854775808", "-9'223'372'036'854'775'808"),
+	)
+})
@@ -0,0 +1,18 @@
+// Package csvio reads and writes decimal columns of CSV files, maps named
+// columns to struct fields by tags:
+//
+//	type Entry struct {
+//		Date   string              `csv:"Date"`
+//		Amount decimal.Decimal     `csv:"Amount"`
+//		Fee    decimal.NullDecimal `csv:"Fee"`
+//	}
+//
+// Fields of type decimal.Decimal, decimal.NullDecimal and string can be mapped,
+// fields without csv tag or tagged "-" are ignored.
+//
+// Decimal cells are parsed and formatted by Format, configures decimal mark,
+// thousands separator and sign convention, such as "(1.234,56)" for
+// -1234.56. Empty cells are NULL, read into Decimal fields is an error.
+package csvio
+
+const tag = "math-csvio"
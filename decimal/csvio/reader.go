@@ -0,0 +1,110 @@
+package csvio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"reflect"
+)
+
+// Reader reads CSV records into struct fields, the first record is the
+// header naming columns.
+type Reader struct {
+	// Format is the format of decimal columns not in Columns.
+	Format Format
+
+	// Columns are formats of specific columns by name.
+	Columns map[string]Format
+
+	r      *csv.Reader
+	header []string
+	index  map[string]int // column index by name
+	record []string
+	row    int
+	fields fieldCache
+}
+
+// NewReader returns Reader reads from r, configure r for the CSV dialect,
+// such as r.Comma = ';'.
+func NewReader(r *csv.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// Header returns column names of the header record, reads it if not read yet.
+func (r *Reader) Header() ([]string, error) {
+	if r.header == nil {
+		header, err := r.r.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.row++
+		r.record = header
+		r.header = append([]string(nil), header...)
+		r.index = make(map[string]int, len(header))
+		for i, name := range header {
+			if _, ok := r.index[name]; !ok {
+				r.index[name] = i
+			}
+		}
+	}
+	return r.header, nil
+}
+
+// Record returns the raw record last read.
+func (r *Reader) Record() []string {
+	return r.record
+}
+
+// Read reads next record into struct v points to, returns io.EOF if no more
+// records. Returns error if a mapped column missing in header, or *Error if
+// a cell can not be parsed.
+func (r *Reader) Read(v interface{}) error {
+	if reflect.ValueOf(v).Kind() != reflect.Ptr {
+		return fmt.Errorf("[%s] expect pointer to struct, got %T", tag, v)
+	}
+	rv, fields, err := r.fields.structOf(v)
+	if err != nil {
+		return err
+	}
+	if _, err := r.Header(); err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if _, ok := r.index[f.column]; !ok {
+			return fmt.Errorf("[%s] missing column %q in CSV header", tag, f.column)
+		}
+	}
+
+	record, err := r.r.Read()
+	if err != nil {
+		return err
+	}
+	r.row++
+	r.record = record
+
+	for _, f := range fields {
+		i := r.index[f.column]
+		if i >= len(record) {
+			return &Error{r.row, f.column, fmt.Errorf("[%s] missing cell", tag)}
+		}
+		cell := record[i]
+		fv := rv.Field(f.index)
+		if f.kind == kindString {
+			fv.SetString(cell)
+			continue
+		}
+
+		d, err := formatOf(r.Format, r.Columns, f.column).Parse(cell)
+		if err != nil {
+			return &Error{r.row, f.column, err}
+		}
+		if f.kind == kindNullDecimal {
+			fv.Set(reflect.ValueOf(d))
+			continue
+		}
+		if !d.Valid {
+			return &Error{r.row, f.column, ErrNull}
+		}
+		fv.Set(reflect.ValueOf(d.Decimal))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package csvio
+
+import (
+	"encoding/csv"
+
+	"github.com/redforks/math/decimal"
+)
+
+// Writer writes struct fields as CSV records, header record written before
+// the first record.
+type Writer struct {
+	// Format is the format of decimal columns not in Columns.
+	Format Format
+
+	// Columns are formats of specific columns by name.
+	Columns map[string]Format
+
+	w           *csv.Writer
+	wroteHeader bool
+	record      []string
+	fields      fieldCache
+}
+
+// NewWriter returns Writer writes to w, configure w for the CSV dialect, such
+// as w.Comma = ';'.
+func NewWriter(w *csv.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes mapped fields of struct v, or v points to, as a record in
+// order of fields. NULL written as empty cell.
+func (w *Writer) Write(v interface{}) error {
+	rv, fields, err := w.fields.structOf(v)
+	if err != nil {
+		return err
+	}
+
+	if !w.wroteHeader {
+		header := make([]string, len(fields))
+		for i, f := range fields {
+			header[i] = f.column
+		}
+		if err := w.w.Write(header); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+
+	w.record = w.record[:0]
+	for _, f := range fields {
+		fv := rv.Field(f.index)
+		var cell string
+		switch f.kind {
+		case kindString:
+			cell = fv.String()
+		case kindDecimal:
+			cell = formatOf(w.Format, w.Columns, f.column).Format(fv.Interface().(decimal.Decimal))
+		case kindNullDecimal:
+			if d := fv.Interface().(decimal.NullDecimal); d.Valid {
+				cell = formatOf(w.Format, w.Columns, f.column).Format(d.Decimal)
+			}
+		}
+		w.record = append(w.record, cell)
+	}
+	return w.w.Write(w.record)
+}
+
+// Flush writes buffered data to the underlying writer, returns error
+// occurred during writing or flushing.
+func (w *Writer) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
@@ -3,16 +3,17 @@ package decimal
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding"
 	"encoding/binary"
 	"fmt"
 	"log"
 	"math"
-	"regexp"
-	"strings"
+	"math/big"
 
 	"gopkg.in/mgo.v2/bson"
 
 	"strconv"
+	"unsafe"
 )
 
 const tag = "decimal"
@@ -29,44 +30,60 @@ func FromInt(i int64) Decimal {
 	return Decimal{i, 0}
 }
 
+// New create Decimal of digits * 10^-scale, such as New(123, 2) is 1.23.
+// Panics if scale out of range.
+func New(digits int64, scale int) Decimal {
+	if err := checkScale(scale); err != nil {
+		panic(err.Error())
+	}
+	return Decimal{digits, uint8(scale)}
+}
+
 // FromString create decimal from string, scale set from fragment part of number.
 // Such as '3.00', scale is 2.
 func FromString(s string) (Decimal, error) {
 	return FromStringWithScale(s, 0)
 }
 
+// ParseBytes create decimal from bytes, same as FromString(string(b)) but
+// not allocate.
+func ParseBytes(b []byte) (Decimal, error) {
+	// FromString() never keeps a reference of its argument if succeeded.
+	d, err := FromString(*(*string)(unsafe.Pointer(&b)))
+	if pe, ok := err.(*ParseError); ok {
+		// error may outlive b, detach from it.
+		pe.Str = string(b)
+	}
+	return d, err
+}
+
 // FromStringWithScale create decimal from string, with specific scale.
-// Use number's actual scale if it larger than specific scale. Examples:
+// Leading and trailing whitespaces are ignored, exponent notation such as
+// "1.5e-3" accepted. Use number's actual scale if it larger than specific
+// scale. Examples:
 //
 //  FromStringWithScale("3", 2) // 3.00
 //  FromStringWithScale("3.33", 0) // 3.33
+//  FromStringWithScale("1.5E+2", 0) // 150
+//  FromStringWithScale("1e-3", 0) // 0.001
+//
+// Returns *ParseError if str is not a number or its effective number out of
+// range.
 func FromStringWithScale(str string, scale int) (Decimal, error) {
 	if err := checkScale(scale); err != nil {
 		return Decimal{}, err
 	}
 
-	s := str
-	actScale, dotIdx := 0, strings.IndexRune(s, '.')
-	if dotIdx != -1 {
-		actScale = len(s) - 1 - dotIdx
-		s = s[0:dotIdx] + s[dotIdx+1:]
-	}
-
-	digits, err := strconv.ParseInt(s, 10, 64)
+	digits, actScale, pos, err := parse(str)
 	if err != nil {
-		e := err.(*strconv.NumError)
-		switch e.Err {
-		case strconv.ErrSyntax:
-			return Decimal{}, fmt.Errorf("[%s] \"%s\" not a number", tag, str)
-		case strconv.ErrRange:
-			return Decimal{}, fmt.Errorf("[%s] \"%s\" effective number out of range", tag, str)
-		default:
-			return Decimal{}, err
-		}
+		return Decimal{}, &ParseError{str, pos, err}
 	}
 
 	if scale > actScale {
-		digits *= powerOf10(scale - actScale)
+		var ok bool
+		if digits, ok = mulPow10(digits, scale-actScale); !ok {
+			return Decimal{}, &ParseError{str, len(str), ErrRange}
+		}
 	} else {
 		scale = actScale
 	}
@@ -74,47 +91,85 @@ func FromStringWithScale(str string, scale int) (Decimal, error) {
 		return Decimal{}, err
 	}
 
-	// TODO: scale out of range > 10 < 0
-	return Decimal{digits, uint8(scale)}, err
+	return Decimal{digits, uint8(scale)}, nil
 }
 
-// FromFloat convert float to decimal
-func FromFloat(v float64, scale uint8) Decimal {
-	return Decimal{
-		digits: int64(v * math.Pow10(int(scale))),
-		scale:  scale,
+// FromFloat convert float to decimal, round to specific scale. Rounding is
+// based on the exact binary value of v, half away from zero, such as 0.29 at
+// scale 2 is 0.29, 0.125 at scale 2 is 0.13. Returns error if v is NaN, Inf,
+// or out of range.
+func FromFloat(v float64, scale uint8) (Decimal, error) {
+	if err := checkScale(int(scale)); err != nil {
+		return Decimal{}, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return Decimal{}, fmt.Errorf("[%s] float %v not a finite number", tag, v)
+	}
+
+	// float64 has 53 bits mantissa, 10^scale less than 2^60, 128 bits precision
+	// keeps all computation exact.
+	f := new(big.Float).SetPrec(128).SetFloat64(v)
+	f.Mul(f, new(big.Float).SetInt64(powerOf10(int(scale))))
+	i, _ := f.Int(nil)
+	frac := new(big.Float).SetPrec(128).Sub(f, new(big.Float).SetInt(i))
+	if frac.Abs(frac).Cmp(big.NewFloat(0.5)) >= 0 {
+		i.Add(i, big.NewInt(int64(f.Sign())))
+	}
+
+	if !i.IsInt64() {
+		return Decimal{}, fmt.Errorf("[%s] float %v out of range", tag, v)
 	}
+	return Decimal{i.Int64(), scale}, nil
+}
+
+// FromFloatShortest convert float to decimal, use the shortest decimal
+// representation that round trips to v, like strconv.FormatFloat(v, 'f', -1, 64).
+// Such as 0.1 is 0.1, 100 is 100. Returns error if v is NaN, Inf, or out of
+// range.
+func FromFloatShortest(v float64) (Decimal, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return Decimal{}, fmt.Errorf("[%s] float %v not a finite number", tag, v)
+	}
+
+	d, err := FromString(strconv.FormatFloat(v, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}, fmt.Errorf("[%s] float %v out of range", tag, v)
+	}
+	return d, nil
 }
 
 // String implement fmt.Stringer interface, return decimal value in string format,
 // appended 0 to scales. Such as 3.00, use ShortString() to get '3'.
 func (d Decimal) String() string {
-	if d.scale == 0 {
-		return strconv.FormatInt(d.digits, 10)
-	}
+	var buf [24]byte
+	return string(d.AppendString(buf[:0]))
+}
 
-	var s string
-	isNegative := d.digits < 0
-	if isNegative {
-		s = strconv.FormatInt(-d.digits, 10)
-	} else {
-		s = strconv.FormatInt(d.digits, 10)
+// AppendString appends String() of the value to dst and returns the extended
+// buffer, no allocation if dst has enough capacity, 22 bytes at most.
+func (d Decimal) AppendString(dst []byte) []byte {
+	var buf [20]byte
+	digits := strconv.AppendUint(buf[:0], absUint64(d.digits), 10)
+	if d.digits < 0 {
+		dst = append(dst, '-')
 	}
 
-	dotIdx := len(s) - int(d.scale)
-	switch {
-	case dotIdx == 0:
-		s = "0." + s
-	case dotIdx < 0:
-		s = "0." + strings.Repeat("0", -dotIdx) + s
-	default:
-		s = s[0:dotIdx] + "." + s[dotIdx:]
+	scale := int(d.scale)
+	if scale == 0 {
+		return append(dst, digits...)
 	}
 
-	if isNegative {
-		s = "-" + s
+	if n := len(digits) - scale; n > 0 {
+		dst = append(dst, digits[:n]...)
+		digits = digits[n:]
+	} else {
+		dst = append(dst, '0')
 	}
-	return s
+	dst = append(dst, '.')
+	for i := len(digits); i < scale; i++ {
+		dst = append(dst, '0')
+	}
+	return append(dst, digits...)
 }
 
 // GoString implement fmt.GoStringer interface. Adding 'm' suffix to result of String().
@@ -122,35 +177,17 @@ func (d Decimal) GoString() string {
 	return d.String() + "m"
 }
 
-var rightDotZero *regexp.Regexp
-
-func init() {
-	var err error
-	rightDotZero, err = regexp.Compile(`\.0*$`)
-	if err != nil {
-		panic(err)
-	}
-}
-
 // ShortString convert current value to string, removing ending 0s.
 // Such as 3.00, returns 3.
 func (d Decimal) ShortString() string {
-	if d.IsZero() {
-		return "0"
-	}
-
-	r := d.String()
-	if d.scale == 0 {
-		return r
-	}
+	var buf [24]byte
+	return string(d.AppendShortString(buf[:0]))
+}
 
-	if strings.ContainsRune(r, '.') {
-		r = strings.TrimRight(r, "0")
-		if r[len(r)-1] == '.' {
-			return r[:len(r)-1]
-		}
-	}
-	return r
+// AppendShortString appends ShortString() of the value to dst and returns the
+// extended buffer, no allocation if dst has enough capacity.
+func (d Decimal) AppendShortString(dst []byte) []byte {
+	return d.Normalize().AppendString(dst)
 }
 
 // Scale return scale of this decimal value.
@@ -158,15 +195,41 @@ func (d Decimal) Scale() uint8 {
 	return d.scale
 }
 
+// Coefficient returns digits of the value without decimal point, such as 123
+// for 1.23, value equals to Coefficient() * 10^-Scale().
+func (d Decimal) Coefficient() int64 {
+	return d.digits
+}
+
+// Precision returns number of significant digits, the number of digits of
+// Coefficient(), such as 3 for 1.23, 0.0123 and 1.00, 1 for zero.
+func (d Decimal) Precision() int {
+	n := 1
+	for v := d.digits / 10; v != 0; v /= 10 {
+		n++
+	}
+	return n
+}
+
+// IntPart returns integer part of the value, truncated toward zero, such as 1
+// for 1.9, -1 for -1.9.
+func (d Decimal) IntPart() int64 {
+	return d.digits / powerOf10(int(d.scale))
+}
+
+// Frac returns fractional part of the value in the same scale, such as 0.90
+// for 1.90, -0.9 for -1.9. d equals to FromInt(d.IntPart()).Add(d.Frac()).
+func (d Decimal) Frac() Decimal {
+	return Decimal{d.digits % powerOf10(int(d.scale)), d.scale}
+}
+
 // Int64 convert current value to int64, round tenth fragment.
 func (d Decimal) Int64() int64 {
 	if d.scale == 0 {
 		return d.digits
 	}
 
-	r := d.digits
-	r /= powerOf10(int(d.scale - 1))
-	return roundLastDecimalBit(r)
+	return roundDivPow10(d.digits, int(d.scale))
 }
 
 // Float64 convert current value to float.
@@ -177,7 +240,8 @@ func (d Decimal) Float64() float64 {
 	return float64(d.digits) / math.Pow10(int(d.scale))
 }
 
-// Round decimal to specific scale.
+// Round decimal to specific scale. Panics with ErrOverflow if digits of
+// result out of int64 range, such as round 10 to scale 18.
 func (d Decimal) Round(scale int) Decimal {
 	if err := checkScale(scale); err != nil {
 		panic(err.Error())
@@ -189,11 +253,9 @@ func (d Decimal) Round(scale int) Decimal {
 	case diff == 0:
 		return d
 	case diff > 0:
-		digits *= powerOf10(diff)
+		digits = mustMulPow10(digits, diff)
 	default:
-		diff = -diff
-		digits /= powerOf10(diff - 1)
-		digits = roundLastDecimalBit(digits)
+		digits = roundDivPow10(digits, -diff)
 	}
 
 	return Decimal{digits, uint8(scale)}
@@ -211,24 +273,31 @@ func (d Decimal) Sign() int {
 	}
 }
 
-// Neg returns negative value
+// Neg returns negative value. Panics with ErrOverflow if digits is min int64.
 func (d Decimal) Neg() Decimal {
+	if d.digits == math.MinInt64 {
+		panic(ErrOverflow)
+	}
 	return Decimal{-d.digits, d.scale}
 }
 
+// Abs returns absolute value. Panics with ErrOverflow if digits is min int64.
+func (d Decimal) Abs() Decimal {
+	if d.digits == math.MinInt64 {
+		panic(ErrOverflow)
+	}
+	return Decimal{abs(d.digits), d.scale}
+}
+
 // Add this value with other value, use two values' highest scale as result scale, such as
 // 3.45 + 1 = 4.45.
 func (d Decimal) Add(other Decimal) Decimal {
-	va, vb, scale := d.digits, other.digits, d.scale
-	diff := int(d.scale) - int(other.scale)
-	switch {
-	case diff > 0:
-		vb = vb * powerOf10(diff)
-	case diff < 0:
-		scale = other.scale
-		va = va * powerOf10(-diff)
+	va, vb, scale := d.align(other)
+	r := va + vb
+	if (r > va) != (vb > 0) {
+		panic(ErrOverflow)
 	}
-	return Decimal{va + vb, scale}
+	return Decimal{r, scale}
 }
 
 // AddToScale this value with other value round to specific scale.
@@ -236,14 +305,35 @@ func (d Decimal) AddToScale(other Decimal, scale int) Decimal {
 	return d.Add(other).Round(scale)
 }
 
-// Sub subtract the other value.
+// Sub subtract the other value, use two values' highest scale as result
+// scale.
 func (d Decimal) Sub(other Decimal) Decimal {
-	return d.Add(other.Neg())
+	va, vb, scale := d.align(other)
+	r := va - vb
+	if (r < va) != (vb > 0) {
+		panic(ErrOverflow)
+	}
+	return Decimal{r, scale}
 }
 
 // SubToScale subtract the other value to specific scale.
 func (d Decimal) SubToScale(other Decimal, scale int) Decimal {
-	return d.AddToScale(other.Neg(), scale)
+	return d.Sub(other).Round(scale)
+}
+
+// align returns digits of d and other rescaled to the higher scale of them,
+// panics with ErrOverflow if out of range.
+func (d Decimal) align(other Decimal) (va, vb int64, scale uint8) {
+	va, vb, scale = d.digits, other.digits, d.scale
+	diff := int(d.scale) - int(other.scale)
+	switch {
+	case diff > 0:
+		vb = mustMulPow10(vb, diff)
+	case diff < 0:
+		scale = other.scale
+		va = mustMulPow10(va, -diff)
+	}
+	return va, vb, scale
 }
 
 // Mul multiply the other value.
@@ -251,18 +341,25 @@ func (d Decimal) Mul(other Decimal) Decimal {
 	return d.MulToScale(other, max(d.scale, other.scale))
 }
 
-// MulToScale multiply the other value and round to specific scale
+// MulToScale multiply the other value and round to specific scale. Product
+// computed in 128 bits, panics with ErrOverflow only if the result out of
+// range.
 func (d Decimal) MulToScale(other Decimal, scale int) Decimal {
-	digits := d.digits * other.digits
+	if err := checkScale(scale); err != nil {
+		panic(err.Error())
+	}
+
+	p := mul64(absUint64(d.digits), absUint64(other.digits))
 	scaleDiff := int(d.scale) + int(other.scale) - scale
-	switch {
-	case scaleDiff > 0:
-		digits /= powerOf10(scaleDiff - 1)
-		digits = roundLastDecimalBit(digits)
-	case scaleDiff < 0:
-		digits *= powerOf10(-scaleDiff)
+	if scaleDiff >= 0 {
+		p = p.roundDivPow10(scaleDiff)
+	} else {
+		var ok bool
+		if p, ok = p.mulPow10(-scaleDiff); !ok {
+			panic(ErrOverflow)
+		}
 	}
-	return Decimal{digits, uint8(scale)}
+	return Decimal{mustInt64(p, (d.digits < 0) != (other.digits < 0)), uint8(scale)}
 }
 
 // Div the other value, scale use max scale of current and other decimal.
@@ -270,37 +367,131 @@ func (d Decimal) Div(other Decimal) Decimal {
 	return d.DivToScale(other, max(d.scale, other.scale))
 }
 
-// DivToScale the other value and round result to specific scale.
+// DivToScale the other value and round result to specific scale. Dividend
+// scaled in 128 bits, panics with ErrOverflow only if the result out of range.
 func (d Decimal) DivToScale(other Decimal, scale int) Decimal {
-	scaleDiff := scale - (int(d.scale) - int(other.scale))
-	digits := d.digits
-	if scaleDiff > 0 {
-		digits *= powerOf10(scaleDiff + 1)
-		digits /= other.digits
-		digits = roundLastDecimalBit(digits)
+	if err := checkScale(scale); err != nil {
+		panic(err.Error())
+	}
+
+	if other.digits == 0 {
+		panic(ErrDivisionByZero)
+	}
+
+	// q = d.digits * 10^scaleDiff / other.digits
+	n, m := uint128{0, absUint64(d.digits)}, absUint64(other.digits)
+	scaleDiff := scale - int(d.scale) + int(other.scale)
+	if scaleDiff < 0 {
+		// divide by m, truncated, then 10^-scaleDiff with rounding, the
+		// same as rounding the exact quotient.
+		n, _ = n.quoRem(m)
+		n = n.roundDivPow10(-scaleDiff)
 	} else {
-		digits /= other.digits
-		if scaleDiff < 0 {
-			scaleDiff = -scaleDiff
-			digits /= powerOf10(scaleDiff - 1)
-			digits = roundLastDecimalBit(digits)
+		var ok bool
+		if n, ok = n.mulPow10(scaleDiff); !ok {
+			// |result| >= 2^128 / 2^63
+			panic(ErrOverflow)
+		}
+
+		var r uint64
+		if n, r = n.quoRem(m); r >= m-r {
+			// round half away from zero
+			n = n.add(1)
 		}
 	}
-	return Decimal{digits, uint8(scale)}
+	return Decimal{mustInt64(n, (d.digits < 0) != (other.digits < 0)), uint8(scale)}
+}
+
+// QuoRem divide the other value, returns quotient truncated toward zero to
+// specific scale, and remainder r that q * other + r == d exactly. Scale of
+// remainder is max(d.Scale(), scale + other.Scale()), trailing zeros beyond
+// MaxScale dropped, remainder has the same sign as d. Returns
+// ErrDivisionByZero if other is zero, ErrOverflow if quotient out of range,
+// ErrPrecisionLoss if remainder has non-zero digits beyond MaxScale.
+func (d Decimal) QuoRem(other Decimal, scale int) (q, r Decimal, err error) {
+	if err = checkScale(scale); err != nil {
+		return
+	}
+	if other.digits == 0 {
+		return q, r, ErrDivisionByZero
+	}
+
+	remScale := scale + int(other.scale)
+	if remScale < int(d.scale) {
+		remScale = int(d.scale)
+	}
+	if remScale > MaxScale {
+		return d.quoRemBig(other, scale, remScale)
+	}
+
+	// align dividend and divisor to remScale, quotient of them is in scale.
+	n, ok := mulPow10(d.digits, remScale-int(d.scale))
+	if !ok {
+		return d.quoRemBig(other, scale, remScale)
+	}
+	m, ok := mulPow10(other.digits, remScale-scale-int(other.scale))
+	if !ok {
+		// |m| greater than any int64, |d| < |m|
+		return Decimal{0, uint8(scale)}, Decimal{n, uint8(remScale)}, nil
+	}
+
+	if n == -maxVal-1 && m == -1 {
+		return q, r, ErrOverflow
+	}
+	return Decimal{n / m, uint8(scale)}, Decimal{n % m, uint8(remScale)}, nil
+}
+
+// quoRemBig is QuoRem() in big.Int, if dividend aligned to remScale out of
+// int64 range, or remScale greater than MaxScale.
+func (d Decimal) quoRemBig(other Decimal, scale, remScale int) (q, r Decimal, err error) {
+	n := new(big.Int).Mul(big.NewInt(d.digits), bigPowerOf10(remScale-int(d.scale)))
+	m := new(big.Int).Mul(big.NewInt(other.digits), bigPowerOf10(remScale-scale-int(other.scale)))
+	bq, br := n.QuoRem(n, m, new(big.Int))
+	if !bq.IsInt64() {
+		return q, r, ErrOverflow
+	}
+
+	ten, digit := big.NewInt(10), new(big.Int)
+	for ; remScale > MaxScale; remScale-- {
+		if m.QuoRem(br, ten, digit); digit.Sign() != 0 {
+			return q, r, ErrPrecisionLoss
+		}
+		br.Set(m)
+	}
+	// |r| <= |d| and |r| < |other| * 10^-scale, r fits in int64 at remScale.
+	return Decimal{bq.Int64(), uint8(scale)}, Decimal{br.Int64(), uint8(remScale)}, nil
+}
+
+// Mod returns remainder of integer division d / other, has the same sign as d.
+// Such as 7.5 mod 2 is 1.5, -7.5 mod 2 is -1.5. Returns ErrDivisionByZero if
+// other is zero.
+func (d Decimal) Mod(other Decimal) (Decimal, error) {
+	_, r, err := d.QuoRem(other, 0)
+	return r, err
+}
+
+// DivInt returns integer quotient of d / other truncated toward zero, scale
+// set to zero. Such as 7.5 / 2 is 3, -7.5 / 2 is -3. Returns ErrDivisionByZero
+// if other is zero.
+func (d Decimal) DivInt(other Decimal) (Decimal, error) {
+	q, _, err := d.QuoRem(other, 0)
+	return q, err
 }
 
 // Cmp the other value return -1 if < other, 1 if > other, 0 if equal.
-// Cmp ignore scale, so 0.00 equals to 0
+// Cmp ignore scale, so 0.00 equals to 0. Never overflows, scales aligned in
+// 128 bits.
 func (d Decimal) Cmp(other Decimal) int {
-	sub := d.Sub(other)
-	switch {
-	case sub.digits > 0:
-		return 1
-	case sub.digits < 0:
-		return -1
-	default:
-		return 0
+	sa, sb := d.Sign(), other.Sign()
+	if sa != sb || sa == 0 {
+		return cmpInt64(int64(sa), int64(sb))
 	}
+
+	// |digits| * 10^18 < 2^127, never overflows.
+	scale := max(d.scale, other.scale)
+	a, _ := uint128{0, absUint64(d.digits)}.mulPow10(scale - int(d.scale))
+	b, _ := uint128{0, absUint64(other.digits)}.mulPow10(scale - int(other.scale))
+	return a.cmp(b) * sa
 }
 
 // LT returns true if current value less than other
@@ -365,6 +556,22 @@ const (
 	maxVal int64 = 1<<63 - 1
 )
 
+// MaxScale is the max supported scale of Decimal, scale of a Decimal can be any
+// of [0, MaxScale]. Digits and scale together must fit in int64, such as
+// 9.223372036854775807 is the max value at scale 18.
+const MaxScale = 18
+
+var (
+	// ErrOverflow indicates that digits of the result out of int64 range.
+	// Operations not returning error, such as Add() and Round(), panic with
+	// ErrOverflow.
+	ErrOverflow = fmt.Errorf("[%s] value out of range", tag)
+
+	// ErrDivisionByZero indicates that divisor is zero. Div() and DivToScale()
+	// panic with ErrDivisionByZero.
+	ErrDivisionByZero = fmt.Errorf("[%s] division by zero", tag)
+)
+
 // FromDecimal128 convert IEEE 754 decimal128 to Decimal. Decimal128 has greater range than Decimal,
 // FromDecimal128 expect the argument must in range of Decimal.
 func FromDecimal128(low, high uint64) Decimal {
@@ -383,7 +590,7 @@ func FromDecimal128(low, high uint64) Decimal {
 	}
 
 	scale = -(scale - 6176)
-	if scale > 8 || scale < 0 {
+	if scale > MaxScale || scale < 0 {
 		panic("FromDecimal128 scale out of range")
 	}
 	if low > uint64(maxVal) {
@@ -458,15 +665,53 @@ func (d *Decimal) SetBSON(raw bson.Raw) error {
 }
 
 func (d Decimal) MarshalJSON() ([]byte, error) {
-	return []byte(d.String()), nil
+	return d.AppendString(nil), nil
 }
 
 func (d *Decimal) UnmarshalJSON(buf []byte) error {
 	var err error
-	*d, err = FromString(string(buf))
+	*d, err = ParseBytes(buf)
+	return err
+}
+
+// MarshalText implement encoding.TextMarshaler interface, same format as String().
+func (d Decimal) MarshalText() ([]byte, error) {
+	return d.AppendString(nil), nil
+}
+
+// UnmarshalText implement encoding.TextUnmarshaler interface, scale set from
+// fragment part of number, same as FromString().
+func (d *Decimal) UnmarshalText(text []byte) error {
+	var err error
+	*d, err = ParseBytes(text)
 	return err
 }
 
+// MarshalBinary implement encoding.BinaryMarshaler interface. Encoded as varint
+// of digits followed by one scale byte.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, binary.MaxVarintLen64+1)
+	n := binary.PutVarint(buf, d.digits)
+	buf[n] = d.scale
+	return buf[:n+1], nil
+}
+
+// UnmarshalBinary implement encoding.BinaryUnmarshaler interface, decode data
+// encoded by MarshalBinary().
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	digits, n := binary.Varint(data)
+	if n <= 0 || len(data) != n+1 {
+		return fmt.Errorf("[%s] invalid binary data %x", tag, data)
+	}
+
+	scale := int(data[n])
+	if err := checkScale(scale); err != nil {
+		return err
+	}
+	*d = Decimal{digits, uint8(scale)}
+	return nil
+}
+
 // Zero returns zero decimal value with specific scale.
 func Zero(scale int) Decimal {
 	if err := checkScale(scale); err != nil {
@@ -476,6 +721,13 @@ func Zero(scale int) Decimal {
 	return Decimal{0, uint8(scale)}
 }
 
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
 func max(a, b uint8) int {
 	if a > b {
 		return int(a)
@@ -483,17 +735,59 @@ func max(a, b uint8) int {
 	return int(b)
 }
 
-var tenth = [18]int64{1, 10, 100, 1000, 10000, 100000, 1000000, 10000000, 100000000, 1000000000,
+var tenth = [MaxScale + 1]int64{1, 10, 100, 1000, 10000, 100000, 1000000, 10000000, 100000000, 1000000000,
 	10000000000, 100000000000, 1000000000000, 10000000000000, 100000000000000, 1000000000000000,
-	10000000000000000, 100000000000000000}
+	10000000000000000, 100000000000000000, 1000000000000000000}
 
 func powerOf10(n int) int64 {
 	return tenth[n]
 }
 
+// mulPow10 returns v * 10^n, ok is false if result overflows int64.
+func mulPow10(v int64, n int) (r int64, ok bool) {
+	if n == 0 {
+		return v, true
+	}
+	p := powerOf10(n)
+	if v > maxVal/p || v < -maxVal/p {
+		return 0, false
+	}
+	return v * p, true
+}
+
+// mustMulPow10 returns v * 10^n, panics with ErrOverflow if result overflows
+// int64.
+func mustMulPow10(v int64, n int) int64 {
+	r, ok := mulPow10(v, n)
+	if !ok {
+		panic(ErrOverflow)
+	}
+	return r
+}
+
+// roundDivPow10 returns v / 10^n, round half away from zero. n must greater
+// than 0.
+func roundDivPow10(v int64, n int) int64 {
+	if n > len(tenth) {
+		// |v| < 10^19, always round to zero.
+		return 0
+	}
+	return roundLastDecimalBit(v / powerOf10(n-1))
+}
+
+// mustInt64 returns u as int64, negative if neg is true, panics with
+// ErrOverflow if out of range.
+func mustInt64(u uint128, neg bool) int64 {
+	v, ok := u.toInt64(neg)
+	if !ok {
+		panic(ErrOverflow)
+	}
+	return v
+}
+
 // checkScale checks scale, return non-nil error if out of range
 func checkScale(scale int) error {
-	if scale >= 10 || scale < 0 {
+	if scale > MaxScale || scale < 0 {
 		return fmt.Errorf("[%s] scale %d out of range", tag, scale)
 	}
 	return nil
@@ -512,6 +806,10 @@ func roundLastDecimalBit(v int64) int64 {
 }
 
 var (
-	_ bson.Getter = Decimal{}
-	_ bson.Setter = &Decimal{}
+	_ bson.Getter                = Decimal{}
+	_ bson.Setter                = &Decimal{}
+	_ encoding.TextMarshaler     = Decimal{}
+	_ encoding.TextUnmarshaler   = &Decimal{}
+	_ encoding.BinaryMarshaler   = Decimal{}
+	_ encoding.BinaryUnmarshaler = &Decimal{}
 )
@@ -1,8 +1,13 @@
 package decimal_test
 
 import (
+	"encoding/hex"
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/testing/matcher"
 
 	"testing"
 )
@@ -11,3 +16,26 @@ func TestDecimal(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Decimal Suite")
 }
+
+func toDecimal(s string) (d decimal.Decimal) {
+	Ω(decimal.FromString(s)).Should(matcher.Save(&d))
+	return
+}
+
+// toDecimals parse space separated decimal strings.
+func toDecimals(s string) []decimal.Decimal {
+	r := []decimal.Decimal{}
+	for _, f := range strings.Fields(s) {
+		r = append(r, toDecimal(f))
+	}
+	return r
+}
+
+// unhex decodes space separated hex string, panics if s not valid hex.
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(strings.Replace(s, " ", "", -1))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
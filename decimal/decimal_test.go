@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"math/rand"
 	"strconv"
+	"testing"
 
 	"gopkg.in/mgo.v2/bson"
 
@@ -34,39 +38,58 @@ var _ = Describe("Decimal", func() {
 		Ω(op(x, y)).Should(Equal(expected))
 	}
 
-	DescribeTable("FromString", func(s string) {
+	DescribeTable("FromString", func(s, exp string) {
 		d, err := decimal.FromString(s)
 		Ω(err).Should(Succeed())
-		Ω(d.String()).Should(Equal(s))
+		Ω(d.String()).Should(Equal(exp))
 	},
-		Entry("zero", "0"),
-		Entry("Zero with scale", "0.00"),
-		Entry("Very big", "123456789012345678"),
-		Entry("Very small", "0.123456789"),
-		Entry("Negative", "-1.30"),
+		Entry("zero", "0", "0"),
+		Entry("Zero with scale", "0.00", "0.00"),
+		Entry("Very big", "123456789012345678", "123456789012345678"),
+		Entry("Very small", "0.123456789", "0.123456789"),
+		Entry("Max scale", "-9.223372036854775808", "-9.223372036854775808"),
+		Entry("Negative", "-1.30", "-1.30"),
+		Entry("Trailing zero beyond max scale", "1.0e-18", "0.000000000000000001"),
+		Entry("Zero beyond max scale", "0e-20", "0.000000000000000000"),
+		Entry("Trailing zeros beyond max scale", "-0.1234567890000000000", "-0.123456789000000000"),
 	)
 
 	DescribeTable("FromString error", func(s, errMsg string) {
 		_, err := decimal.FromString(s)
 		Ω(err).Should(MatchError(errMsg))
 	},
-		Entry("Empty string", "", `[decimal] "" not a number`),
-		Entry("Not a number", "abc", `[decimal] "abc" not a number`),
-		Entry("Like a number", "1.3.3", `[decimal] "1.3.3" not a number`),
-		Entry("Effective number too large", "12345678901234567890", `[decimal] "12345678901234567890" effective number out of range`),
-		Entry("Scale out of range", "0.1234567890", `[decimal] scale 10 out of range`),
+		Entry("Empty string", "", `[decimal] "" not a number, unexpected end`),
+		Entry("Not a number", "abc", `[decimal] "abc" not a number, unexpected 'a' at position 0`),
+		Entry("Like a number", "1.3.3", `[decimal] "1.3.3" not a number, unexpected '.' at position 3`),
+		Entry("Effective number too large", "12345678901234567890", `[decimal] "12345678901234567890" effective number out of range at position 19`),
+		Entry("Scale out of range", "0.1234567890123456789", `[decimal] scale 19 out of range`),
+		Entry("Sign only", "-", `[decimal] "-" not a number, unexpected end`),
+		Entry("Dot only", ".", `[decimal] "." not a number, unexpected end`),
+		Entry("Two signs", "+-1", `[decimal] "+-1" not a number, unexpected '-' at position 1`),
+		Entry("Space inside", " 1 2 ", `[decimal] " 1 2 " not a number, unexpected ' ' at position 2`),
+		Entry("No exponent digits", "1e", `[decimal] "1e" not a number, unexpected end`),
+		Entry("No exponent digits after sign", "1e+ ", `[decimal] "1e+ " not a number, unexpected ' ' at position 3`),
+		Entry("Exponent without mantissa", "e3", `[decimal] "e3" not a number, unexpected 'e' at position 0`),
+		Entry("Exponent not integer", "1e1.5", `[decimal] "1e1.5" not a number, unexpected '.' at position 3`),
+		Entry("Exponent too large", "12e18", `[decimal] "12e18" effective number out of range at position 2`),
+		Entry("Exponent very large", "1e99999999999", `[decimal] "1e99999999999" effective number out of range at position 1`),
+		Entry("Exponent too small", "1e-19", `[decimal] scale 19 out of range`),
+		Entry("Exponent too small with trailing zero", "1.10e-18", `[decimal] scale 19 out of range`),
+		Entry("Non ascii", "1,0", `[decimal] "1,0" not a number, unexpected ',' at position 1`),
 	)
 
 	DescribeTable("FromStringWithScale error", func(s string, scale int, errMsg string) {
 		_, err := decimal.FromStringWithScale(s, scale)
 		Ω(err).Should(MatchError(errMsg))
 	},
-		Entry("Empty string", "", 0, `[decimal] "" not a number`),
-		Entry("Not a number", "abc", 0, `[decimal] "abc" not a number`),
-		Entry("Like a number", "1.2.3", 0, `[decimal] "1.2.3" not a number`),
-		Entry("Effective number too large", "12345678901234567890", 0, `[decimal] "12345678901234567890" effective number out of range`),
-		Entry("Actual scale out of range", "0.1234567890", 1, `[decimal] scale 10 out of range`),
-		Entry("Scale out of range 1", "0.0", 10, `[decimal] scale 10 out of range`),
+		Entry("Empty string", "", 0, `[decimal] "" not a number, unexpected end`),
+		Entry("Not a number", "abc", 0, `[decimal] "abc" not a number, unexpected 'a' at position 0`),
+		Entry("Like a number", "1.2.3", 0, `[decimal] "1.2.3" not a number, unexpected '.' at position 3`),
+		Entry("Effective number too large", "12345678901234567890", 0, `[decimal] "12345678901234567890" effective number out of range at position 19`),
+		Entry("Effective number too large after scaled", "1234567890123", 9, `[decimal] "1234567890123" effective number out of range at position 13`),
+		Entry("Actual scale out of range", "0.1234567890123456789", 1, `[decimal] scale 19 out of range`),
+		Entry("Scale out of range 1", "0.0", 19, `[decimal] scale 19 out of range`),
+		Entry("Max scale overflow", "10", 18, `[decimal] "10" effective number out of range at position 2`),
 		Entry("Scale out of range 2", "0.0", -1, `[decimal] scale -1 out of range`),
 	)
 
@@ -79,18 +102,84 @@ var _ = Describe("Decimal", func() {
 		Entry("Scale matches 2", "0.00", 2, "0.00"),
 		Entry("Scale larger", "3.3", 3, "3.300"),
 		Entry("Scale smaller", "3.333", 1, "3.333"),
+		Entry("Day rate", "0.000123456789", 12, "0.000123456789"),
+		Entry("Exponent", "1.5E+2", 0, "150"),
+		Entry("Exponent keep scale", "1.50e1", 0, "15.0"),
+		Entry("Negative exponent", "1e-3", 0, "0.001"),
+		Entry("Negative exponent scale larger", "-1e-3", 4, "-0.0010"),
+		Entry("Zero exponent", "3.30e0", 0, "3.30"),
+		Entry("Zero with large exponent", "0e99999", 0, "0"),
+		Entry("Leading plus", "+3.3", 0, "3.3"),
+		Entry("Surrounding whitespaces", " \t-3.3\r\n", 0, "-3.3"),
+		Entry("No integer part", ".5", 0, "0.5"),
+		Entry("No fragment part", "5.", 0, "5"),
+		Entry("Min int64", "-9223372036854775808", 0, "-9223372036854775808"),
 	)
 
+	It("FromString ParseError", func() {
+		_, err := decimal.FromString("1.2.3")
+		var pe *decimal.ParseError
+		Ω(errors.As(err, &pe)).Should(BeTrue())
+		Ω(pe.Pos).Should(Equal(3))
+		Ω(errors.Is(err, decimal.ErrSyntax)).Should(BeTrue())
+
+		_, err = decimal.FromString("12345678901234567890")
+		Ω(errors.Is(err, decimal.ErrRange)).Should(BeTrue())
+	})
+
 	DescribeTable("FromFloat", func(v float64, scale int, exp string) {
-		d := decimal.FromFloat(v, uint8(scale))
+		d, err := decimal.FromFloat(v, uint8(scale))
+		Ω(err).Should(Succeed())
 		Ω(d.String()).Should(Equal(exp))
 	},
 		Entry("Zero", 0.0, 0, "0"),
 		Entry("One", 1.0, 0, "1"),
 		Entry("Round", 1.33333, 2, "1.33"),
-		Entry("Round up", 1.66666, 2, "1.66"),
+		Entry("Round up", 1.66666, 2, "1.67"),
 		Entry("negative", -1.44444, 2, "-1.44"),
+		Entry("negative round up", -1.66666, 2, "-1.67"),
 		Entry("scale up", 1.0, 2, "1.00"),
+		Entry("Not truncated", 0.29, 2, "0.29"),
+		Entry("Exact half", 0.125, 2, "0.13"),
+		Entry("Exact half negative", -0.125, 2, "-0.13"),
+		Entry("Binary value less than half", 1.005, 2, "1.00"),
+		Entry("Large", 1e18, 0, "1000000000000000000"),
+		Entry("Scale 9", 0.123456789, 9, "0.123456789"),
+		Entry("Max scale", 0.123456789012345678, 18, "0.123456789012345677"),
+	)
+
+	DescribeTable("FromFloat error", func(v float64, scale int, errMsg string) {
+		_, err := decimal.FromFloat(v, uint8(scale))
+		Ω(err).Should(MatchError(errMsg))
+	},
+		Entry("NaN", math.NaN(), 2, "[decimal] float NaN not a finite number"),
+		Entry("Inf", math.Inf(1), 2, "[decimal] float +Inf not a finite number"),
+		Entry("-Inf", math.Inf(-1), 2, "[decimal] float -Inf not a finite number"),
+		Entry("Too large", 1e19, 0, "[decimal] float 1e+19 out of range"),
+		Entry("Too large after scaled", -1e17, 2, "[decimal] float -1e+17 out of range"),
+		Entry("Scale out of range", 1.0, 19, "[decimal] scale 19 out of range"),
+	)
+
+	DescribeTable("FromFloatShortest", func(v float64, exp string) {
+		d, err := decimal.FromFloatShortest(v)
+		Ω(err).Should(Succeed())
+		Ω(d.String()).Should(Equal(exp))
+	},
+		Entry("Zero", 0.0, "0"),
+		Entry("Integer", 100.0, "100"),
+		Entry("Fragment", 0.1, "0.1"),
+		Entry("0.29", 0.29, "0.29"),
+		Entry("Negative", -1.005, "-1.005"),
+	)
+
+	DescribeTable("FromFloatShortest error", func(v float64, errMsg string) {
+		_, err := decimal.FromFloatShortest(v)
+		Ω(err).Should(MatchError(errMsg))
+	},
+		Entry("NaN", math.NaN(), "[decimal] float NaN not a finite number"),
+		Entry("Inf", math.Inf(1), "[decimal] float +Inf not a finite number"),
+		Entry("Too large", 1e19, "[decimal] float 1e+19 out of range"),
+		Entry("Scale too large", 1e-19, "[decimal] float 1e-19 out of range"),
 	)
 
 	It("GoStringer", func() {
@@ -120,8 +209,52 @@ var _ = Describe("Decimal", func() {
 		Entry("Ten", "10.00", "10"),
 		Entry("Ten 2", "10", "10"),
 		Entry("3.30", "3.30", "3.3"),
+		Entry("Negative", "-0.50", "-0.5"),
+		Entry("Min int64", "-9.223372036854775808", "-9.223372036854775808"),
+	)
+
+	DescribeTable("AppendString", func(str string) {
+		d, err := decimal.FromString(str)
+		Ω(err).Should(Succeed())
+		Ω(string(d.AppendString([]byte("x=")))).Should(Equal("x=" + d.String()))
+		Ω(string(d.AppendShortString([]byte("x=")))).Should(Equal("x=" + d.ShortString()))
+	},
+		Entry("integer", "-300"),
+		Entry("fragment", "3.30"),
+		Entry("leading zeros", "-0.0030"),
+		Entry("min int64", "-9.223372036854775808"),
 	)
 
+	It("ParseBytes", func() {
+		b := []byte(" 3.30 ")
+		d, err := decimal.ParseBytes(b)
+		Ω(err).Should(Succeed())
+		Ω(d.String()).Should(Equal("3.30"))
+
+		b = []byte("3.x")
+		_, err = decimal.ParseBytes(b)
+		copy(b, "abc")
+		Ω(err).Should(MatchError(`[decimal] "3.x" not a number, unexpected 'x' at position 2`))
+	})
+
+	It("no allocation", func() {
+		d, err := decimal.FromString("-12345.6700")
+		Ω(err).Should(Succeed())
+		buf, text := make([]byte, 0, 32), []byte("-12345.6700")
+		Ω(testing.AllocsPerRun(100, func() {
+			d.AppendString(buf)
+		})).Should(BeZero())
+		Ω(testing.AllocsPerRun(100, func() {
+			d.AppendShortString(buf)
+		})).Should(BeZero())
+		Ω(testing.AllocsPerRun(100, func() {
+			decimal.ParseBytes(text)
+		})).Should(BeZero())
+		Ω(testing.AllocsPerRun(100, func() {
+			_ = d.String()
+		})).Should(BeNumerically("<=", 1))
+	})
+
 	DescribeTable("FromInt", func(i int64) {
 		d := decimal.FromInt(i)
 		Ω(d.Scale()).Should(Equal(uint8(0)))
@@ -159,6 +292,33 @@ var _ = Describe("Decimal", func() {
 		Entry("decimal", "-1.456", -1.456),
 	)
 
+	It("New", func() {
+		Ω(decimal.New(123, 2).String()).Should(Equal("1.23"))
+		Ω(decimal.New(-5, 0)).Should(Equal(decimal.FromInt(-5)))
+		Ω(recoverPanic(func() {
+			decimal.New(1, 19)
+		})).Should(Equal("[decimal] scale 19 out of range"))
+	})
+
+	DescribeTable("Parts", func(s string, coefficient int64, precision int, intPart int64, frac string) {
+		d, err := decimal.FromString(s)
+		Ω(err).Should(Succeed())
+		Ω(d.Coefficient()).Should(Equal(coefficient))
+		Ω(d.Precision()).Should(Equal(precision))
+		Ω(d.IntPart()).Should(Equal(intPart))
+		Ω(d.Frac().String()).Should(Equal(frac))
+		Ω(decimal.FromInt(d.IntPart()).Add(d.Frac())).Should(Equal(d))
+		Ω(decimal.New(d.Coefficient(), int(d.Scale()))).Should(Equal(d))
+	},
+		Entry("zero", "0", int64(0), 1, int64(0), "0"),
+		Entry("zero scaled", "0.00", int64(0), 1, int64(0), "0.00"),
+		Entry("integer", "120", int64(120), 3, int64(120), "0"),
+		Entry("decimal", "1.90", int64(190), 3, int64(1), "0.90"),
+		Entry("negative", "-1.9", int64(-19), 2, int64(-1), "-0.9"),
+		Entry("fraction", "0.0123", int64(123), 3, int64(0), "0.0123"),
+		Entry("max", "-9.223372036854775808", int64(math.MinInt64), 19, int64(-9), "-0.223372036854775808"),
+	)
+
 	Context("Compute", func() {
 
 		DescribeTable("Negate", func(s, exp string) {
@@ -172,6 +332,28 @@ var _ = Describe("Decimal", func() {
 			Entry("Negative", "-3.4456", "3.4456"),
 		)
 
+		DescribeTable("Abs", func(s, exp string) {
+			d, err := decimal.FromString(s)
+			Ω(err).Should(Succeed())
+			Ω(d.Abs().String()).Should(Equal(exp))
+		},
+			Entry("Zero", "0.00", "0.00"),
+			Entry("Positive", "3.456", "3.456"),
+			Entry("Negative", "-3.4456", "3.4456"),
+		)
+
+		It("Abs overflow", func() {
+			Ω(recoverPanic(func() {
+				decimal.New(math.MinInt64, 2).Abs()
+			})).Should(Equal(decimal.ErrOverflow))
+		})
+
+		It("Negate overflow", func() {
+			Ω(recoverPanic(func() {
+				decimal.New(math.MinInt64, 2).Neg()
+			})).Should(Equal(decimal.ErrOverflow))
+		})
+
 		DescribeTable("Add", func(a, b, c string) {
 			assertBinOp(a, b, c, func(x, y decimal.Decimal) interface{} {
 				return x.Add(y)
@@ -209,6 +391,20 @@ var _ = Describe("Decimal", func() {
 			Entry("Add up to integer", "2.8", "1.2", "1.6"),
 			Entry("fragment", "0.0007", "0.0003", "0.0004"),
 			Entry("fragment and integer", "300", "0.3", "299.7"),
+			Entry("min int64", "-1", "-9223372036854775808", "9223372036854775807"),
+			Entry("to min int64", "-9223372036854775807", "1", "-9223372036854775808"),
+		)
+
+		DescribeTable("Subtract overflow", func(a, b string) {
+			x, y := toDecimal2(a, b)
+			Ω(recoverPanic(func() {
+				x.Sub(y)
+			})).Should(Equal(decimal.ErrOverflow))
+		},
+			Entry("negate min int64", "0", "-9223372036854775808"),
+			Entry("below min int64", "-9223372036854775808", "1"),
+			Entry("above max int64", "9223372036854775807", "-1"),
+			Entry("rescale", "1", "-922337203685477580.8"),
 		)
 
 		DescribeTable("SubtractToScale", func(a, b, c string, scale int) {
@@ -249,6 +445,27 @@ var _ = Describe("Decimal", func() {
 			Entry("shrink scale round down", "1.455", "1", "1", 0),
 			Entry("shrink scale round down 2", "1.445", "0.1", "0.14", 2),
 			Entry("extend scale more", "1.0", "2", "2.000", 3),
+			Entry("day rate", "10000.00", "0.000123456789", "1.234568", 6),
+			Entry("day rate keep scale", "10000.00", "0.000123456789", "1.23456789000", 11),
+			Entry("max scale", "0.000000001", "0.000000001", "0.000000000000000001", 18),
+			Entry("max scale round to zero", "0.000000000000000001", "0.000000000000000001", "0", 0),
+			Entry("max scale round up", "0.000000000000000005", "0.1", "0.000000000000000001", 18),
+			Entry("product exceeds int64", "123456.7890", "98765.4321", "12193263111.2635", 4),
+			Entry("product of max digits", "9.223372036854775807", "9.223372036854775807", "85.07059173023461585", 17),
+			Entry("min int64", "-4611686018427387904", "2", "-9223372036854775808", 0),
+			Entry("min int64 times one", "-9.223372036854775808", "1", "-9.223372036854775808", 18),
+		)
+
+		DescribeTable("MultiplyToScale overflow", func(a, b string, scale int) {
+			x, y := toDecimal2(a, b)
+			Ω(recoverPanic(func() {
+				x.MulToScale(y, scale)
+			})).Should(Equal(decimal.ErrOverflow))
+		},
+			Entry("digits overflow", "123456789012", "123456789012", 0),
+			Entry("scale overflow", "1000", "1000", 18),
+			Entry("max int64 plus one", "4611686018427387904", "2", 0),
+			Entry("negate min int64", "-9223372036854775808", "-1", 0),
 		)
 
 		DescribeTable("Div", func(a, b, c string) {
@@ -271,9 +488,9 @@ var _ = Describe("Decimal", func() {
 		)
 
 		It("div by zero", func() {
-			Ω(func() {
+			Ω(recoverPanic(func() {
 				decimal.FromInt(1).Div(decimal.FromInt(0))
-			}).Should(Panic())
+			})).Should(Equal(decimal.ErrDivisionByZero))
 		})
 
 		DescribeTable("DivToScale", func(a, b, c string, scale int) {
@@ -285,8 +502,165 @@ var _ = Describe("Decimal", func() {
 			Entry("expand scale", "10", "4", "2.50", 2),
 			Entry("shrink scale round up", "1.454", "1", "1.5", 1),
 			Entry("shrink scale round down", "1.454", "1", "1.45", 2),
+			Entry("expand to day rate", "0.0365", "365", "0.000100000000", 12),
+			Entry("expand to max scale", "1", "3", "0.333333333333333333", 18),
+			Entry("expand to max scale round up", "2", "3", "0.666666666666666667", 18),
+			Entry("expand round up negative", "-2", "3", "-0.67", 2),
+			Entry("expand round up negative divisor", "2", "-3", "-0.67", 2),
+			Entry("expand round half", "1", "8", "0.13", 2),
+			Entry("shrink from max scale", "0.123456789012345678", "1", "0.12", 2),
+			Entry("large quotient", "9.000000000000000000", "0.000000000000000001", "9000000000000000000", 0),
+			Entry("dividend scaled exceeds int64", "9223372036854775807", "9223372036854775807", "1.000000000000000000", 18),
+			Entry("max digits to max scale", "9.223372036854775807", "3", "3.074457345618258602", 18),
+			Entry("min int64", "-9223372036854775808", "1", "-9223372036854775808", 0),
+			Entry("shrink round half up", "0.25", "1", "0.3", 1),
+			Entry("shrink with divisor round half up", "0.0050", "0.1", "0.1", 1),
+		)
+
+		DescribeTable("DivToScale overflow", func(a, b string, scale int) {
+			x, y := toDecimal2(a, b)
+			Ω(recoverPanic(func() {
+				x.DivToScale(y, scale)
+			})).Should(Equal(decimal.ErrOverflow))
+		},
+			Entry("quotient out of range", "10", "1", 18),
+			Entry("negate min int64", "-9223372036854775808", "-1", 0),
+			Entry("128 bits overflow", "9223372036854775807", "0.000000000000000001", 18),
+		)
+
+		It("MulToScale and DivToScale match big.Rat", func() {
+			randDecimal := func() decimal.Decimal {
+				digits := rand.Int63() >> uint(rand.Intn(63))
+				if rand.Intn(2) == 0 {
+					digits = -digits
+				}
+				return decimal.New(digits, rand.Intn(decimal.MaxScale+1))
+			}
+			toRat := func(d decimal.Decimal) *big.Rat {
+				r, _ := new(big.Rat).SetString(d.String())
+				return r
+			}
+			check := func(op string, exact *big.Rat, scale int, f func() decimal.Decimal) {
+				exp, err := decimal.FromString(exact.FloatString(scale))
+				if err != nil {
+					Ω(recoverPanic(func() { f() })).Should(Equal(decimal.ErrOverflow), "%s %s", op, exact)
+					return
+				}
+				Ω(f()).Should(Equal(exp), "%s %s", op, exact)
+			}
+
+			for i := 0; i < 2000; i++ {
+				x, y, scale := randDecimal(), randDecimal(), rand.Intn(decimal.MaxScale+1)
+				check("mul", new(big.Rat).Mul(toRat(x), toRat(y)), scale, func() decimal.Decimal {
+					return x.MulToScale(y, scale)
+				})
+				if !y.IsZero() {
+					check("div", new(big.Rat).Quo(toRat(x), toRat(y)), scale, func() decimal.Decimal {
+						return x.DivToScale(y, scale)
+					})
+				}
+			}
+		})
+
+		DescribeTable("QuoRem", func(a, b string, scale int, q, r string) {
+			x, y := toDecimal2(a, b)
+			expQ, expR := toDecimal2(q, r)
+			actQ, actR, err := x.QuoRem(y, scale)
+			Ω(err).Should(Succeed())
+			Ω(actQ).Should(Equal(expQ))
+			Ω(actR).Should(Equal(expR))
+			Ω(actQ.MulToScale(y, int(actR.Scale())).Add(actR)).Should(Equal(x.Round(int(actR.Scale()))))
+		},
+			Entry("integers", "7", "2", 0, "3", "1"),
+			Entry("exact", "6", "2", 0, "3", "0"),
+			Entry("negative dividend", "-7", "2", 0, "-3", "-1"),
+			Entry("negative divisor", "7", "-2", 0, "-3", "1"),
+			Entry("both negative", "-7", "-2", 0, "3", "-1"),
+			Entry("fragment dividend", "7.5", "2", 0, "3", "1.5"),
+			Entry("fragment divisor", "7", "0.3", 0, "23", "0.1"),
+			Entry("quotient scale", "10", "3", 2, "3.33", "0.01"),
+			Entry("quotient and divisor scale", "10", "0.3", 2, "33.33", "0.001"),
+			Entry("dividend scale larger", "1.00001", "3", 2, "0.33", "0.01001"),
+			Entry("divisor larger than dividend", "1", "3", 0, "0", "1"),
+			Entry("divisor too large to align", "1.000000000000000000", "9000000000", 0, "0", "1.000000000000000000"),
+			Entry("day rate", "0.0365", "365", 12, "0.000100000000", "0.000000000000"),
+			Entry("min int64", "-9223372036854775808", "2", 0, "-4611686018427387904", "0"),
+			Entry("min int64 with remainder", "-9223372036854775808", "3", 0, "-3074457345618258602", "-2"),
+			Entry("min int64 fragment", "-9.223372036854775808", "1", 0, "-9", "-0.223372036854775808"),
+			Entry("remainder scale beyond max scale", "0.5", "0.25", 17, "2.00000000000000000", "0.000000000000000000"),
+			Entry("remainder trailing zero beyond max scale", "0.000000000000000001", "0.5", 17, "0.00000000000000000", "0.000000000000000001"),
+		)
+
+		DescribeTable("QuoRem error", func(a, b string, scale int, exp error) {
+			x, y := toDecimal2(a, b)
+			_, _, err := x.QuoRem(y, scale)
+			Ω(err).Should(Equal(exp))
+		},
+			Entry("division by zero", "1", "0", 0, decimal.ErrDivisionByZero),
+			Entry("division by zero with scale", "1", "0.00", 2, decimal.ErrDivisionByZero),
+			Entry("quotient overflow", "100", "3", 18, decimal.ErrOverflow),
+			Entry("min int64 divided by -1", "-9223372036854775808", "-1", 0, decimal.ErrOverflow),
+			Entry("quotient overflow with remainder beyond max scale", "1", "0.000000000000000003", 18, decimal.ErrOverflow),
+			Entry("remainder beyond max scale", "1", "0.3", 18, decimal.ErrPrecisionLoss),
+		)
+
+		It("QuoRem aligned dividend beyond int64", func() {
+			q, r, err := decimal.FromInt(10).QuoRem(decimal.FromInt(3), 18)
+			Ω(err).Should(Succeed())
+			Ω(q).Should(Equal(toDecimal("3.333333333333333333")))
+			Ω(r).Should(Equal(toDecimal("0.000000000000000001")))
+
+			q, r, err = toDecimal("5000000000000000000").QuoRem(toDecimal("5.0"), 0)
+			Ω(err).Should(Succeed())
+			Ω(q).Should(Equal(toDecimal("1000000000000000000")))
+			Ω(r).Should(Equal(toDecimal("0.0")))
+		})
+
+		It("QuoRem scale out of range", func() {
+			_, _, err := decimal.FromInt(1).QuoRem(decimal.FromInt(3), 19)
+			Ω(err).Should(MatchError("[decimal] scale 19 out of range"))
+		})
+
+		DescribeTable("Mod", func(a, b, c string) {
+			assertBinOp(a, b, c, func(x, y decimal.Decimal) interface{} {
+				r, err := x.Mod(y)
+				Ω(err).Should(Succeed())
+				return r
+			})
+		},
+			Entry("integers", "7", "2", "1"),
+			Entry("fragments", "7.5", "2", "1.5"),
+			Entry("negative", "-7.5", "2", "-1.5"),
+			Entry("negative divisor", "7.5", "-2", "1.5"),
+			Entry("fragment divisor", "1", "0.3", "0.1"),
+			Entry("exact", "1.00", "0.25", "0.00"),
+			Entry("min int64", "-9223372036854775808", "3", "-2"),
+			Entry("aligned dividend beyond int64", "5000000000000000001", "5.0", "1.0"),
+		)
+
+		DescribeTable("DivInt", func(a, b, c string) {
+			assertBinOp(a, b, c, func(x, y decimal.Decimal) interface{} {
+				r, err := x.DivInt(y)
+				Ω(err).Should(Succeed())
+				return r
+			})
+		},
+			Entry("integers", "7", "2", "3"),
+			Entry("fragments", "7.5", "2", "3"),
+			Entry("negative", "-7.5", "2", "-3"),
+			Entry("truncate not round", "1.99", "1", "1"),
+			Entry("fragment divisor", "1", "0.3", "3"),
+			Entry("min int64", "-9223372036854775808", "2", "-4611686018427387904"),
+			Entry("aligned dividend beyond int64", "5000000000000000001", "5.0", "1000000000000000000"),
 		)
 
+		It("Mod and DivInt by zero", func() {
+			_, err := decimal.FromInt(1).Mod(decimal.Zero(2))
+			Ω(err).Should(Equal(decimal.ErrDivisionByZero))
+			_, err = decimal.FromInt(1).DivInt(decimal.Zero(2))
+			Ω(err).Should(Equal(decimal.ErrDivisionByZero))
+		})
+
 	})
 
 	DescribeTable("Round", func(s string, scale int, exp string) {
@@ -300,8 +674,30 @@ var _ = Describe("Decimal", func() {
 		Entry("Shrink scale round down", "3.44", 1, "3.4"),
 		Entry("Shrink scale round up negative", "-3.45", 1, "-3.5"),
 		Entry("Shrink scale round down negative", "-3.44", 1, "-3.4"),
+		Entry("Expand to max scale", "3.4", 18, "3.400000000000000000"),
+		Entry("Shrink from max scale", "0.123456789012345678", 12, "0.123456789012"),
+		Entry("Shrink from max scale round up", "0.123456789012345678", 15, "0.123456789012346"),
+		Entry("Shrink from max scale to integer", "9.223372036854775807", 0, "9"),
+		Entry("Shrink from max scale to integer round up", "-0.5", 0, "-1"),
+	)
+
+	DescribeTable("Round overflow", func(s string, scale int) {
+		d, err := decimal.FromString(s)
+		Ω(err).Should(Succeed())
+		Ω(recoverPanic(func() {
+			d.Round(scale)
+		})).Should(Equal(decimal.ErrOverflow))
+	},
+		Entry("Integer to max scale", "10", 18),
+		Entry("Large value", "123456789012345678", 2),
 	)
 
+	It("Round scale out of range", func() {
+		Ω(func() {
+			decimal.FromInt(1).Round(19)
+		}).Should(Panic())
+	})
+
 	Context("GetZero", func() {
 		for i := 0; i < 9; i++ {
 			It(strconv.Itoa(i), func() {
@@ -343,8 +739,30 @@ var _ = Describe("Decimal", func() {
 			Entry("Equal has different scale", "1.00", "1.000", 0),
 			Entry("Less than", "1", "9", -1),
 			Entry("Greater than", "2.1", "2", 1),
+			Entry("Negative less than", "-2.1", "-2", -1),
+			Entry("Negative greater than", "-2", "-2.1", 1),
+			Entry("Zero and negative", "0.00", "-0.000000000000000001", 1),
+			Entry("Large positive and negative", "9223372036854775807", "-9223372036854775808", 1),
+			Entry("Large negative and positive", "-9223372036854775808", "9223372036854775807", -1),
+			Entry("Very different scales", "9223372036854775807", "0.000000000000000001", 1),
+			Entry("Very different scales negative", "-9223372036854775807", "-0.000000000000000001", -1),
+			Entry("Close at max scale", "9.223372036854775807", "9.223372036854775806", 1),
+			Entry("Equal at different scales", "9223372036", "9223372036.000000000", 0),
 		)
 
+		It("Cmp matches big.Rat", func() {
+			for i := 0; i < 1000; i++ {
+				x := decimal.New(rand.Int63()-rand.Int63(), rand.Intn(decimal.MaxScale+1))
+				y := decimal.New(rand.Int63()-rand.Int63(), rand.Intn(decimal.MaxScale+1))
+				if r, err := x.Rescale(int(y.Scale())); err == nil && i%2 == 0 {
+					y = r
+				}
+				rx, _ := new(big.Rat).SetString(x.String())
+				ry, _ := new(big.Rat).SetString(y.String())
+				Ω(x.Cmp(y)).Should(Equal(rx.Cmp(ry)), "%s cmp %s", x, y)
+			}
+		})
+
 		DescribeTable("LessThan", func(a, b string, r bool) {
 			assertBinOp(a, b, r, func(x, y decimal.Decimal) interface{} {
 				return x.LT(y)
@@ -430,6 +848,53 @@ var _ = Describe("Decimal", func() {
 		Ω(json.Unmarshal([]byte("3.30"), &d)).Should(Succeed())
 	})
 
+	It("Text marshal", func() {
+		d, err := decimal.FromString("-3.30")
+		Ω(err).Should(Succeed())
+		Ω(d.MarshalText()).Should(Equal([]byte("-3.30")))
+
+		var back decimal.Decimal
+		Ω(back.UnmarshalText([]byte("-3.30"))).Should(Succeed())
+		Ω(back).Should(Equal(d))
+
+		Ω(back.UnmarshalText([]byte("abc"))).Should(MatchError(`[decimal] "abc" not a number, unexpected 'a' at position 0`))
+	})
+
+	It("Json map key", func() {
+		m := map[decimal.Decimal]int{decimal.FromInt(3): 1}
+		Ω(json.Marshal(m)).Should(Equal([]byte(`{"3":1}`)))
+
+		var back map[decimal.Decimal]int
+		Ω(json.Unmarshal([]byte(`{"3.30":1}`), &back)).Should(Succeed())
+		d, _ := decimal.FromString("3.30")
+		Ω(back).Should(Equal(map[decimal.Decimal]int{d: 1}))
+	})
+
+	DescribeTable("Binary marshal", func(s string, exp []byte) {
+		d, err := decimal.FromString(s)
+		Ω(err).Should(Succeed())
+		Ω(d.MarshalBinary()).Should(Equal(exp))
+
+		var back decimal.Decimal
+		Ω(back.UnmarshalBinary(exp)).Should(Succeed())
+		Ω(back).Should(Equal(d))
+	},
+		Entry("Zero", "0", []byte{0, 0}),
+		Entry("Zero scale 2", "0.00", []byte{0, 2}),
+		Entry("Positive", "3.30", []byte{0x94, 0x05, 2}),
+		Entry("Negative", "-1", []byte{1, 0}),
+	)
+
+	DescribeTable("Binary unmarshal error", func(data []byte) {
+		var d decimal.Decimal
+		Ω(d.UnmarshalBinary(data)).ShouldNot(Succeed())
+	},
+		Entry("Empty", []byte{}),
+		Entry("No scale", []byte{0x94, 0x05}),
+		Entry("Extra bytes", []byte{0, 0, 0}),
+		Entry("Scale out of range", []byte{0, 100}),
+	)
+
 	Context("decimal128", func() {
 
 		DescribeTable("ToDecimal128", func(d decimal.Decimal, expLow, expHigh uint64) {
@@ -461,7 +926,7 @@ var _ = Describe("Decimal", func() {
 		},
 			Entry("too big", uint64(0x8234567890123456), uint64(0x3040000000000000)),
 			Entry("scale too large", uint64(0), uint64(0x3042000000000000)),
-			Entry("scale too small", uint64(0), uint64(0x302e000000000000)),
+			Entry("scale too small", uint64(0), uint64(0x301a000000000000)),
 			Entry("Two big uses high", uint64(0), uint64(0x3040010000000000)),
 		)
 
@@ -476,6 +941,7 @@ var _ = Describe("Decimal", func() {
 			Entry("Zero scale 2", "0.00"),
 			Entry("One", "1"),
 			Entry("Negative one", "-1"),
+			Entry("Max scale", "0.123456789012345678"),
 		)
 
 		var (
@@ -519,3 +985,13 @@ var _ = Describe("Decimal", func() {
 	})
 
 })
+
+// recoverPanic calls f, returns the value f panics with, nil if not panic.
+func recoverPanic(f func()) (r interface{}) {
+	defer func() {
+		r = recover()
+	}()
+
+	f()
+	return nil
+}
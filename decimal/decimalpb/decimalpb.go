@@ -0,0 +1,131 @@
+// Package decimalpb contains Protocol Buffers representations of
+// decimal.Decimal: the compact Decimal message defined in decimal.proto, and
+// conversions to and from well known google.type.Decimal and
+// google.type.Money.
+//
+// Use the Decimal message between services sharing decimal.proto, it keeps
+// scale and is the smallest on the wire. google.type.Decimal is the string
+// form, readable by any client. google.type.Money carries a currency code, its
+// fraction limited to 9 digits.
+package decimalpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative decimal.proto
+
+import (
+	"fmt"
+
+	"github.com/redforks/math/decimal"
+	gdecimal "google.golang.org/genproto/googleapis/type/decimal"
+	"google.golang.org/genproto/googleapis/type/money"
+)
+
+const tag = "math-decimalpb"
+
+const maxNanos = 999999999
+
+// New returns Decimal message of d.
+func New(d decimal.Decimal) *Decimal {
+	return &Decimal{Coefficient: d.Coefficient(), Scale: uint32(d.Scale())}
+}
+
+// NewNull returns Decimal message of d, nil if d is NULL.
+func NewNull(d decimal.NullDecimal) *Decimal {
+	if !d.Valid {
+		return nil
+	}
+	return New(d.Decimal)
+}
+
+// AsDecimal converts x to decimal.Decimal, returns error if x is nil or its
+// scale out of range.
+func (x *Decimal) AsDecimal() (decimal.Decimal, error) {
+	if x == nil {
+		return decimal.Decimal{}, fmt.Errorf("[%s] nil Decimal message", tag)
+	}
+	if x.Scale > decimal.MaxScale {
+		return decimal.Decimal{}, fmt.Errorf("[%s] scale %d out of range", tag, x.Scale)
+	}
+	return decimal.New(x.Coefficient, int(x.Scale)), nil
+}
+
+// AsNullDecimal converts x to decimal.NullDecimal, nil converted to NULL.
+func (x *Decimal) AsNullDecimal() (decimal.NullDecimal, error) {
+	if x == nil {
+		return decimal.NullDecimal{}, nil
+	}
+	d, err := x.AsDecimal()
+	if err != nil {
+		return decimal.NullDecimal{}, err
+	}
+	return decimal.NullDecimal{Decimal: d, Valid: true}, nil
+}
+
+// GoogleDecimal returns google.type.Decimal of d, value formatted by
+// d.String(), such as "1.50".
+func GoogleDecimal(d decimal.Decimal) *gdecimal.Decimal {
+	return &gdecimal.Decimal{Value: d.String()}
+}
+
+// FromGoogleDecimal parses google.type.Decimal by decimal.FromString(),
+// accepts all forms the spec allows, such as "+1.5", ".5", "5." and "1.5e3".
+// Returns error if x is nil, or the value not a number or out of range.
+func FromGoogleDecimal(x *gdecimal.Decimal) (decimal.Decimal, error) {
+	if x == nil {
+		return decimal.Decimal{}, fmt.Errorf("[%s] nil google.type.Decimal message", tag)
+	}
+	return decimal.FromString(x.GetValue())
+}
+
+// Money returns google.type.Money of d in specific currency code, such as
+// 1.50 to units 1 and nanos 500000000. Returns decimal.ErrPrecisionLoss if d
+// has non-zero digits after 9th decimal place.
+func Money(d decimal.Decimal, currencyCode string) (*money.Money, error) {
+	frac, err := d.Frac().Rescale(9)
+	if err != nil {
+		return nil, err
+	}
+	return &money.Money{
+		CurrencyCode: currencyCode,
+		Units:        d.IntPart(),
+		Nanos:        int32(frac.Coefficient()),
+	}, nil
+}
+
+// FromMoney converts google.type.Money to decimal.Decimal of specific scale,
+// such as units 1 and nanos 500000000 to 1.50 at scale 2. Currency code is
+// ignored. Returns error if m is nil, nanos out of range or its sign
+// inconsistent with units, decimal.ErrPrecisionLoss if nanos not fit in
+// scale, such as nanos 5000000 at scale 2, and decimal.ErrOverflow if out of
+// range.
+func FromMoney(m *money.Money, scale int) (decimal.Decimal, error) {
+	if m == nil {
+		return decimal.Decimal{}, fmt.Errorf("[%s] nil google.type.Money message", tag)
+	}
+	if scale < 0 || scale > decimal.MaxScale {
+		return decimal.Decimal{}, fmt.Errorf("[%s] scale %d out of range", tag, scale)
+	}
+
+	units, nanos := m.GetUnits(), m.GetNanos()
+	if nanos < -maxNanos || nanos > maxNanos {
+		return decimal.Decimal{}, fmt.Errorf("[%s] nanos %d out of range", tag, nanos)
+	}
+	if units > 0 && nanos < 0 || units < 0 && nanos > 0 {
+		return decimal.Decimal{}, fmt.Errorf("[%s] sign of units %d and nanos %d mismatch", tag, units, nanos)
+	}
+
+	frac, err := decimal.New(int64(nanos), 9).Rescale(scale)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	i, err := decimal.FromInt(units).Rescale(scale)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	// i and frac have the same sign, overflow if sign of sum changed.
+	a, b := i.Coefficient(), frac.Coefficient()
+	if sum := a + b; a > 0 && sum < 0 || a < 0 && sum >= 0 {
+		return decimal.Decimal{}, decimal.ErrOverflow
+	}
+	return i.Add(frac), nil
+}
@@ -0,0 +1,136 @@
+package decimalpb_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/math/decimal/decimalpb"
+	gdecimal "google.golang.org/genproto/googleapis/type/decimal"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Decimalpb", func() {
+	Context("Decimal", func() {
+		DescribeTable("wire format", func(s string, exp []byte) {
+			d := toDecimal(s)
+			buf, err := proto.Marshal(decimalpb.New(d))
+			Ω(err).Should(Succeed())
+			Ω(buf).Should(Equal(exp))
+
+			var x decimalpb.Decimal
+			Ω(proto.Unmarshal(buf, &x)).Should(Succeed())
+			Ω(x.AsDecimal()).Should(Equal(d))
+		},
+			Entry("zero", "0", []byte{}),
+			Entry("1.23", "1.23", []byte{0x08, 0x7b, 0x10, 0x02}),
+			Entry("-1.0", "-1.0", []byte{0x08, 0xf6, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x10, 0x01}),
+			Entry("0.00", "0.00", []byte{0x10, 0x02}),
+		)
+
+		It("AsDecimal error", func() {
+			_, err := (*decimalpb.Decimal)(nil).AsDecimal()
+			Ω(err).Should(MatchError("[math-decimalpb] nil Decimal message"))
+			_, err = (&decimalpb.Decimal{Coefficient: 1, Scale: 19}).AsDecimal()
+			Ω(err).Should(MatchError("[math-decimalpb] scale 19 out of range"))
+			_, err = (&decimalpb.Decimal{Coefficient: 1, Scale: 19}).AsNullDecimal()
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("NullDecimal", func() {
+			Ω(decimalpb.NewNull(decimal.NullDecimal{})).Should(BeNil())
+			Ω((*decimalpb.Decimal)(nil).AsNullDecimal()).Should(Equal(decimal.NullDecimal{}))
+
+			d := decimal.NullDecimal{Decimal: toDecimal("1.50"), Valid: true}
+			x := decimalpb.NewNull(d)
+			Ω(x.GetCoefficient()).Should(Equal(int64(150)))
+			Ω(x.GetScale()).Should(Equal(uint32(2)))
+			Ω(x.AsNullDecimal()).Should(Equal(d))
+		})
+	})
+
+	Context("google.type.Decimal", func() {
+		It("GoogleDecimal", func() {
+			Ω(decimalpb.GoogleDecimal(toDecimal("-1.50")).GetValue()).Should(Equal("-1.50"))
+		})
+
+		DescribeTable("FromGoogleDecimal", func(s, exp string) {
+			d, err := decimalpb.FromGoogleDecimal(&gdecimal.Decimal{Value: s})
+			Ω(err).Should(Succeed())
+			Ω(d.String()).Should(Equal(exp))
+		},
+			Entry("plain", "1.50", "1.50"),
+			Entry("plus sign", "+1.5", "1.5"),
+			Entry("no integer part", ".5", "0.5"),
+			Entry("no fraction part", "5.", "5"),
+			Entry("exponent", "1.5e3", "1500"),
+			Entry("negative exponent", "-2.5E-3", "-0.0025"),
+		)
+
+		It("FromGoogleDecimal error", func() {
+			_, err := decimalpb.FromGoogleDecimal(nil)
+			Ω(err).Should(MatchError("[math-decimalpb] nil google.type.Decimal message"))
+			_, err = decimalpb.FromGoogleDecimal(&gdecimal.Decimal{Value: "NaN"})
+			Ω(err).Should(HaveOccurred())
+			_, err = decimalpb.FromGoogleDecimal(&gdecimal.Decimal{Value: "1e100"})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("google.type.Money", func() {
+		DescribeTable("Money", func(s string, units int64, nanos int32) {
+			m, err := decimalpb.Money(toDecimal(s), "USD")
+			Ω(err).Should(Succeed())
+			Ω(m.GetCurrencyCode()).Should(Equal("USD"))
+			Ω(m.GetUnits()).Should(Equal(units))
+			Ω(m.GetNanos()).Should(Equal(nanos))
+		},
+			Entry("zero", "0.00", int64(0), int32(0)),
+			Entry("positive", "1.50", int64(1), int32(500000000)),
+			Entry("negative", "-1.75", int64(-1), int32(-750000000)),
+			Entry("negative fraction", "-0.01", int64(0), int32(-10000000)),
+			Entry("nanos", "0.123456789", int64(0), int32(123456789)),
+			Entry("trailing zeros", "2.1234567890000", int64(2), int32(123456789)),
+			Entry("max int64", "9223372036854775807", int64(9223372036854775807), int32(0)),
+		)
+
+		It("Money loses precision", func() {
+			_, err := decimalpb.Money(toDecimal("0.0000000001"), "USD")
+			Ω(err).Should(Equal(decimal.ErrPrecisionLoss))
+		})
+
+		DescribeTable("FromMoney", func(units int64, nanos int32, scale int, exp string) {
+			d, err := decimalpb.FromMoney(&money.Money{CurrencyCode: "USD", Units: units, Nanos: nanos}, scale)
+			Ω(err).Should(Succeed())
+			Ω(d.String()).Should(Equal(exp))
+		},
+			Entry("zero", int64(0), int32(0), 2, "0.00"),
+			Entry("positive", int64(1), int32(500000000), 2, "1.50"),
+			Entry("negative", int64(-1), int32(-750000000), 2, "-1.75"),
+			Entry("negative nanos only", int64(0), int32(-10000000), 2, "-0.01"),
+			Entry("scale 0", int64(12), int32(0), 0, "12"),
+			Entry("max scale", int64(1), int32(1), 18, "1.000000001000000000"),
+			Entry("max nanos", int64(0), int32(999999999), 9, "0.999999999"),
+			Entry("max int64", int64(9223372036854775807), int32(0), 0, "9223372036854775807"),
+			Entry("max at scale 2", int64(92233720368547758), int32(70000000), 2, "92233720368547758.07"),
+			Entry("min at scale 2", int64(-92233720368547758), int32(-80000000), 2, "-92233720368547758.08"),
+		)
+
+		DescribeTable("FromMoney error", func(m *money.Money, scale int, exp interface{}) {
+			_, err := decimalpb.FromMoney(m, scale)
+			Ω(err).Should(MatchError(exp))
+		},
+			Entry("nil", nil, 2, "[math-decimalpb] nil google.type.Money message"),
+			Entry("scale", &money.Money{}, 19, "[math-decimalpb] scale 19 out of range"),
+			Entry("nanos range", &money.Money{Nanos: 1000000000}, 2, "[math-decimalpb] nanos 1000000000 out of range"),
+			Entry("negative nanos range", &money.Money{Nanos: -1000000000}, 2, "[math-decimalpb] nanos -1000000000 out of range"),
+			Entry("sign", &money.Money{Units: 1, Nanos: -1}, 9, "[math-decimalpb] sign of units 1 and nanos -1 mismatch"),
+			Entry("negative sign", &money.Money{Units: -1, Nanos: 1}, 9, "[math-decimalpb] sign of units -1 and nanos 1 mismatch"),
+			Entry("nanos not fit scale", &money.Money{Units: 1, Nanos: 5000000}, 2, decimal.ErrPrecisionLoss),
+			Entry("units overflow", &money.Money{Units: 92233720368547759}, 2, decimal.ErrOverflow),
+			Entry("sum overflow", &money.Money{Units: 92233720368547758, Nanos: 80000000}, 2, decimal.ErrOverflow),
+			Entry("negative sum overflow", &money.Money{Units: -92233720368547758, Nanos: -90000000}, 2, decimal.ErrOverflow),
+		)
+	})
+})
@@ -0,0 +1,4 @@
+package decimal
+
+// RoundApprox exports roundApprox for tests.
+var RoundApprox = roundApprox
@@ -0,0 +1,99 @@
+package decimal_test
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/redforks/math/decimal"
+)
+
+func FuzzTextRoundTrip(f *testing.F) {
+	for _, s := range []string{"0", "0.00", "-1.30", "123456789012345678", "0.123456789"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var d decimal.Decimal
+		if err := d.UnmarshalText([]byte(s)); err != nil {
+			return
+		}
+
+		text, err := d.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back decimal.Decimal
+		if err = back.UnmarshalText(text); err != nil {
+			t.Fatalf("%q: %v", text, err)
+		}
+		if back != d {
+			t.Fatalf("%q: round trip got %#v, want %#v", s, back, d)
+		}
+	})
+}
+
+func FuzzBinaryRoundTrip(f *testing.F) {
+	f.Add([]byte{0, 0})
+	f.Add([]byte{0x94, 0x05, 2})
+	f.Add([]byte{1, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var d decimal.Decimal
+		if err := d.UnmarshalBinary(data); err != nil {
+			return
+		}
+
+		buf, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back decimal.Decimal
+		if err = back.UnmarshalBinary(buf); err != nil {
+			t.Fatalf("%x: %v", buf, err)
+		}
+		if back != d {
+			t.Fatalf("%x: round trip got %#v, want %#v", data, back, d)
+		}
+
+		var nd decimal.NullDecimal
+		if err = nd.UnmarshalBinary(buf); err != nil || !nd.Valid || nd.Decimal != d {
+			t.Fatalf("%x: NullDecimal got %#v, %v", buf, nd, err)
+		}
+	})
+}
+
+func FuzzFromString(f *testing.F) {
+	for _, s := range []string{"0", "-1.30", " +1.5E+2 ", "1e-3", ".5", "5.", "1.2.3", "12345678901234567890"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := decimal.FromString(s)
+		if err != nil {
+			// big.Rat accepts more forms, such as "1/2" and "0x1p-2", only
+			// compare plain decimal numbers.
+			if !errors.Is(err, decimal.ErrSyntax) || strings.Trim(s, "0123456789.eE+-") != "" {
+				return
+			}
+			if _, ok := new(big.Rat).SetString(s); ok {
+				t.Fatalf("%q: big.Rat accepted, but got: %v", s, err)
+			}
+			return
+		}
+
+		exp, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+		if !ok && d.IsZero() {
+			// big.Rat rejects exponent out of int64 range, such as "0e99999999999999999999"
+			return
+		}
+		if !ok {
+			t.Fatalf("%q: big.Rat rejected, but got %#v", s, d)
+		}
+		act, ok := new(big.Rat).SetString(d.String())
+		if !ok || act.Cmp(exp) != 0 {
+			t.Fatalf("%q: got %#v, want %s", s, d, exp.FloatString(int(d.Scale())))
+		}
+	})
+}
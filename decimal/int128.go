@@ -0,0 +1,107 @@
+package decimal
+
+import "math/bits"
+
+// uint128 is an unsigned 128-bit integer, intermediate result of
+// multiplication and division, so that they overflow only if the final result
+// out of range.
+type uint128 struct {
+	hi, lo uint64
+}
+
+// absUint64 returns |v| as uint64, correct for min int64.
+func absUint64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+	return uint64(v)
+}
+
+// mul64 returns a * b.
+func mul64(a, b uint64) uint128 {
+	hi, lo := bits.Mul64(a, b)
+	return uint128{hi, lo}
+}
+
+// mul returns u * v, ok is false if result overflows 128 bits.
+func (u uint128) mul(v uint64) (r uint128, ok bool) {
+	hi, lo := bits.Mul64(u.lo, v)
+	h, l := bits.Mul64(u.hi, v)
+	hi, carry := bits.Add64(hi, l, 0)
+	return uint128{hi, lo}, h == 0 && carry == 0
+}
+
+// mulPow10 returns u * 10^n, ok is false if result overflows 128 bits.
+func (u uint128) mulPow10(n int) (r uint128, ok bool) {
+	for ; n > 0; n -= MaxScale {
+		p := n
+		if p > MaxScale {
+			p = MaxScale
+		}
+		if u, ok = u.mul(uint64(powerOf10(p))); !ok {
+			return u, false
+		}
+	}
+	return u, true
+}
+
+// quoRem returns u / v and u % v, v must not be zero.
+func (u uint128) quoRem(v uint64) (q uint128, r uint64) {
+	q.hi, r = u.hi/v, u.hi%v
+	q.lo, r = bits.Div64(r, u.lo, v)
+	return
+}
+
+// roundDivPow10 returns u / 10^n, round half up. n must not be negative.
+func (u uint128) roundDivPow10(n int) uint128 {
+	if n == 0 {
+		return u
+	}
+
+	// truncate to one more digit, then round by the last digit.
+	for n--; n > 0; n -= MaxScale {
+		p := n
+		if p > MaxScale {
+			p = MaxScale
+		}
+		u, _ = u.quoRem(uint64(powerOf10(p)))
+	}
+	q, r := u.quoRem(10)
+	if r >= 5 {
+		q = q.add(1)
+	}
+	return q
+}
+
+// add returns u + v, wraps if overflows.
+func (u uint128) add(v uint64) uint128 {
+	lo, carry := bits.Add64(u.lo, v, 0)
+	return uint128{u.hi + carry, lo}
+}
+
+// cmp returns -1 if u < v, 1 if u > v, 0 if equal.
+func (u uint128) cmp(v uint128) int {
+	switch {
+	case u.hi < v.hi, u.hi == v.hi && u.lo < v.lo:
+		return -1
+	case u == v:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// toInt64 returns u as int64, negative if neg is true, ok is false if out of
+// int64 range.
+func (u uint128) toInt64(neg bool) (v int64, ok bool) {
+	switch {
+	case u.hi != 0 || u.lo > 1<<63:
+		return 0, false
+	case u.lo == 1<<63:
+		return -maxVal - 1, neg
+	case neg:
+		return -int64(u.lo), true
+	default:
+		return int64(u.lo), true
+	}
+}
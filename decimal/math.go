@@ -0,0 +1,268 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// PowInt returns d^n round to specific scale. Result is exact before rounding,
+// n can be negative. Returns ErrDivisionByZero if d is zero and n is negative,
+// ErrOverflow if result out of range.
+func (d Decimal) PowInt(n int, scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+
+	switch {
+	case n == 0:
+		return Decimal{powerOf10(scale), uint8(scale)}, nil
+	case d.digits == 0 && n < 0:
+		return Decimal{}, ErrDivisionByZero
+	case d.digits == 0:
+		return Decimal{0, uint8(scale)}, nil
+	}
+
+	absN := int64(n)
+	if absN < 0 {
+		absN = -absN
+	}
+
+	// exact result has about absN * bits(digits) bits, use exp(n * ln(d)) if
+	// the exact one is too large to compute.
+	digits := big.NewInt(d.digits)
+	if absN*int64(digits.BitLen()) > 1<<20 {
+		return d.powBig(FromInt(int64(n)), scale)
+	}
+
+	p := new(big.Int).Exp(digits, big.NewInt(absN), nil)
+	if n > 0 {
+		return quoToScale(p, int(d.scale)*int(absN), big.NewInt(1), 0, scale, HalfUp)
+	}
+	return quoToScale(big.NewInt(1), 0, p, int(d.scale)*int(absN), scale, HalfUp)
+}
+
+// Pow returns d^y round to specific scale. d must be positive if y is not an
+// integer. Returns ErrOverflow if result out of range.
+func (d Decimal) Pow(y Decimal, scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+
+	if y.digits%powerOf10(int(y.scale)) == 0 {
+		n := y.digits / powerOf10(int(y.scale))
+		if n >= math.MinInt32 && n <= math.MaxInt32 {
+			return d.PowInt(int(n), scale)
+		}
+	}
+
+	switch {
+	case d.digits < 0:
+		return Decimal{}, fmt.Errorf("[%s] negative number %s to non-integer power %s", tag, d, y)
+	case d.digits == 0 && y.digits < 0:
+		return Decimal{}, ErrDivisionByZero
+	case d.digits == 0:
+		return Decimal{0, uint8(scale)}, nil
+	}
+
+	return d.powBig(y, scale)
+}
+
+// Sqrt returns square root of d round to specific scale. Returns error if d is
+// negative.
+func (d Decimal) Sqrt(scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+	if d.digits < 0 {
+		return Decimal{}, fmt.Errorf("[%s] square root of negative number %s", tag, d)
+	}
+
+	// compute in working scale w, at least one more digit than scale to round.
+	w := scale + 1
+	if half := (int(d.scale) + 1) / 2; half > w {
+		w = half
+	}
+
+	// r = floor(sqrt(d) * 10^w), truncated then round half away from zero is
+	// the same as round the exact value.
+	r := toBig(d, 2*w)
+	r.Sqrt(r)
+	q, rem := r.QuoRem(r, bigPowerOf10(w-scale), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(bigPowerOf10(w-scale)) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return fromBig(q, scale)
+}
+
+// Exp returns e^d round to specific scale. Returns ErrOverflow if result out of
+// range.
+func (d Decimal) Exp(scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+
+	// e^44 > 2^63, e^-44 < 10^-18
+	switch limit := FromInt(44); {
+	case d.GT(limit):
+		return Decimal{}, ErrOverflow
+	case d.LT(limit.Neg()):
+		return Decimal{0, uint8(scale)}, nil
+	}
+
+	return roundApprox(scale, func(prec uint) *big.Float {
+		return bigExp(d.bigFloat(prec), prec)
+	})
+}
+
+// Ln returns natural logarithm of d round to specific scale. Returns error if
+// d is not positive.
+func (d Decimal) Ln(scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+	if d.digits <= 0 {
+		return Decimal{}, fmt.Errorf("[%s] logarithm of non-positive number %s", tag, d)
+	}
+
+	return roundApprox(scale, func(prec uint) *big.Float {
+		return bigLn(d.bigFloat(prec), prec)
+	})
+}
+
+// powBig returns e^(y * ln(|d|)) round to scale, negative if d is negative and
+// y is an odd integer.
+func (d Decimal) powBig(y Decimal, scale int) (Decimal, error) {
+	// d is negative only if y is an integer
+	neg := d.digits < 0 && (y.digits/powerOf10(int(y.scale)))%2 != 0
+
+	return roundApprox(scale, func(prec uint) *big.Float {
+		// error of ln(d) amplified by y.
+		yf := y.bigFloat(prec)
+		if e := yf.MantExp(nil); e > 0 {
+			prec += uint(e)
+		}
+		x := d.bigFloat(prec)
+		p := new(big.Float).SetPrec(prec).Mul(yf, bigLn(x.Abs(x), prec))
+		if p.Cmp(big.NewFloat(64)) > 0 {
+			// overflow anyway, avoid computing a huge number
+			p.SetInt64(64)
+		}
+		r := bigExp(p, prec)
+		if neg {
+			r.Neg(r)
+		}
+		return r
+	})
+}
+
+// bigFloat returns d as big.Float in specific precision.
+func (d Decimal) bigFloat(prec uint) *big.Float {
+	r := new(big.Float).SetPrec(prec).SetInt64(d.digits)
+	return r.Quo(r, new(big.Float).SetPrec(prec).SetInt64(powerOf10(int(d.scale))))
+}
+
+// roundApprox round result of compute to scale, half away from zero. compute
+// returns an approximation in specific precision, roundApprox increase the
+// precision until it is clear which way to round.
+func roundApprox(scale int, compute func(prec uint) *big.Float) (Decimal, error) {
+	var (
+		half = big.NewFloat(0.5)
+		i    *big.Int
+	)
+	// result digits less than 10^19, 19 + scale + guard digits enough to
+	// round if the guard digits not close to half.
+	for guard := 16; guard <= 256; guard *= 2 {
+		prec := uint(float64(19+scale+guard)*math.Log2(10)) + 64
+		f := compute(prec)
+		f.Mul(f, new(big.Float).SetInt(bigPowerOf10(scale)))
+		if f.MantExp(nil) > 64 {
+			return Decimal{}, ErrOverflow
+		}
+
+		i, _ = f.Int(nil)
+		frac := new(big.Float).SetPrec(prec).Sub(f, new(big.Float).SetInt(i))
+		diff := frac.Abs(frac).Sub(frac, half)
+		if diff.Sign() >= 0 {
+			i.Add(i, big.NewInt(int64(f.Sign())))
+		}
+
+		// error of compute is far less than 10^-guard digits of the result,
+		// rounding is settled if frac not that close to half.
+		if diff.Sign() != 0 && diff.MantExp(nil) > -int(float64(guard)*math.Log2(10)) {
+			break
+		}
+	}
+
+	// still close to half after max precision, treat as exact half, i rounded
+	// away from zero.
+	return fromBig(i, scale)
+}
+
+// bigExp returns e^x in specific precision.
+func bigExp(x *big.Float, prec uint) *big.Float {
+	// e^x = (e^(x/2^k))^(2^k), reduce x to |x| < 2^-8 so taylor series
+	// converges fast. Each square lost one bit of precision, compensate it.
+	k := 0
+	if e := x.MantExp(nil); e > -8 {
+		k = e + 8
+	}
+	prec += uint(k)
+
+	r := new(big.Float).SetPrec(prec).SetMantExp(x, -k)
+	sum := new(big.Float).SetPrec(prec).SetInt64(1)
+	term := new(big.Float).SetPrec(prec).SetInt64(1)
+	n := new(big.Float).SetPrec(prec)
+	for i := int64(1); ; i++ {
+		term.Mul(term, r)
+		term.Quo(term, n.SetInt64(i))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	for ; k > 0; k-- {
+		sum.Mul(sum, sum)
+	}
+	return sum
+}
+
+// bigLn returns ln(x) in specific precision, x must be positive.
+func bigLn(x *big.Float, prec uint) *big.Float {
+	if x.Cmp(big.NewFloat(0.5)) >= 0 && x.Cmp(big.NewFloat(2)) <= 0 {
+		return lnSeries(x, prec)
+	}
+
+	// x = m * 2^e, ln(x) = ln(m) + e * ln(2)
+	m := new(big.Float).SetPrec(prec)
+	e := x.MantExp(m)
+	r := lnSeries(m, prec)
+	ln2 := lnSeries(big.NewFloat(2), prec)
+	return r.Add(r, ln2.Mul(ln2, new(big.Float).SetPrec(prec).SetInt64(int64(e))))
+}
+
+// lnSeries returns ln(x) by series 2 * (z + z^3/3 + z^5/5 + ...), where
+// z = (x-1)/(x+1). x should in [0.5, 2] to converge fast.
+func lnSeries(x *big.Float, prec uint) *big.Float {
+	one := new(big.Float).SetPrec(prec).SetInt64(1)
+	z := new(big.Float).SetPrec(prec).Sub(x, one)
+	z.Quo(z, new(big.Float).SetPrec(prec).Add(x, one))
+	if z.Sign() == 0 {
+		return z
+	}
+
+	z2 := new(big.Float).SetPrec(prec).Mul(z, z)
+	sum := new(big.Float).SetPrec(prec).Set(z)
+	term := new(big.Float).SetPrec(prec).Set(z)
+	t, n := new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec)
+	for i := int64(3); ; i += 2 {
+		term.Mul(term, z2)
+		t.Quo(term, n.SetInt64(i))
+		if t.Sign() == 0 || t.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, t)
+	}
+	return sum.Mul(sum, big.NewFloat(2))
+}
@@ -0,0 +1,193 @@
+package decimal_test
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+)
+
+var _ = Describe("Math", func() {
+	toRat := func(d decimal.Decimal) *big.Rat {
+		r, ok := new(big.Rat).SetString(d.String())
+		Ω(ok).Should(BeTrue())
+		return r
+	}
+
+	DescribeTable("PowInt", func(x string, n, scale int, exp string) {
+		Ω(toDecimal(x).PowInt(n, scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("zero power", "3.3", 0, 2, "1.00"),
+		Entry("zero base", "0", 3, 2, "0.00"),
+		Entry("zero to zero", "0", 0, 0, "1"),
+		Entry("integer", "3", 4, 0, "81"),
+		Entry("fragment", "1.1", 2, 2, "1.21"),
+		Entry("round half up", "1.5", 2, 1, "2.3"),
+		Entry("negative base odd", "-1.5", 3, 3, "-3.375"),
+		Entry("negative base even", "-1.5", 2, 2, "2.25"),
+		Entry("negative power", "2", -2, 2, "0.25"),
+		Entry("negative power round", "3", -1, 4, "0.3333"),
+		Entry("negative base and power", "-2", -3, 4, "-0.1250"),
+		Entry("monthly rate", "1.005", 360, 12, "6.022575212263"),
+		Entry("daily rate", "1.000123456789", 10950, 12, "3.864253032369"),
+		Entry("huge power", "1.000000000000000001", 1000000, 18, "1.000000000001000000"),
+		Entry("huge negative power", "0.5", -62, 0, "4611686018427387904"),
+	)
+
+	DescribeTable("PowInt error", func(x string, n, scale int, exp error) {
+		_, err := toDecimal(x).PowInt(n, scale)
+		Ω(err).Should(Equal(exp))
+	},
+		Entry("zero to negative power", "0", -1, 2, decimal.ErrDivisionByZero),
+		Entry("overflow", "10", 19, 0, decimal.ErrOverflow),
+		Entry("overflow after scaled", "10", 17, 2, decimal.ErrOverflow),
+		Entry("huge power overflow", "1.1", 1000000, 2, decimal.ErrOverflow),
+	)
+
+	It("PowInt matches big.Rat", func() {
+		for i := 0; i < 200; i++ {
+			d := toDecimal(big.NewRat(rand.Int63n(2000000)-1000000, 10000).FloatString(rand.Intn(5)))
+			n, scale := rand.Intn(7)-3, rand.Intn(10)
+			if d.IsZero() && n < 0 {
+				continue
+			}
+
+			r := new(big.Rat).SetInt64(1)
+			for j := 0; j < n; j++ {
+				r.Mul(r, toRat(d))
+			}
+			for j := 0; j > n; j-- {
+				r.Quo(r, toRat(d))
+			}
+			exp, err := decimal.FromString(r.FloatString(scale))
+			if err != nil {
+				continue // out of range
+			}
+			Ω(d.PowInt(n, scale)).Should(Equal(exp), "%s^%d", d, n)
+		}
+	})
+
+	DescribeTable("Pow", func(x, y string, scale int, exp string) {
+		Ω(toDecimal(x).Pow(toDecimal(y), scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("integer power", "1.5", "2.00", 2, "2.25"),
+		Entry("negative base integer power", "-2", "3", 0, "-8"),
+		Entry("square root", "2", "0.5", 18, "1.414213562373095049"),
+		Entry("exact root", "4", "0.5", 2, "2.00"),
+		Entry("exact half", "0.25", "0.5", 0, "1"),
+		Entry("fragment power", "1.05", "2.5", 10, "1.1297263219"),
+		Entry("negative fragment power", "0.9", "-3.7", 12, "1.476735499462"),
+		Entry("zero base", "0", "0.5", 2, "0.00"),
+	)
+
+	DescribeTable("Pow error", func(x, y string, exp string) {
+		_, err := toDecimal(x).Pow(toDecimal(y), 2)
+		Ω(err).Should(MatchError(exp))
+	},
+		Entry("negative base", "-2", "0.5", "[decimal] negative number -2 to non-integer power 0.5"),
+		Entry("zero to negative power", "0", "-0.5", "[decimal] division by zero"),
+		Entry("overflow", "10", "18.5", "[decimal] value out of range"),
+	)
+
+	DescribeTable("Sqrt", func(x string, scale int, exp string) {
+		Ω(toDecimal(x).Sqrt(scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("zero", "0", 2, "0.00"),
+		Entry("exact", "4", 0, "2"),
+		Entry("exact fragment", "0.0625", 2, "0.25"),
+		Entry("exact to scale", "0.0625", 1, "0.3"),
+		Entry("two", "2", 18, "1.414213562373095049"),
+		Entry("round down", "2", 2, "1.41"),
+		Entry("high scale input", "0.000000000000000002", 0, "0"),
+		Entry("high scale input to high scale", "0.000000000000000002", 9, "0.000000001"),
+		Entry("max value", "9223372036854775807", 0, "3037000500"),
+	)
+
+	It("Sqrt negative", func() {
+		_, err := toDecimal("-1").Sqrt(2)
+		Ω(err).Should(MatchError("[decimal] square root of negative number -1"))
+	})
+
+	It("Sqrt matches big.Float", func() {
+		for i := 0; i < 200; i++ {
+			scale := rand.Intn(10)
+			d := decimal.FromInt(rand.Int63n(1<<40)).DivToScale(decimal.FromInt(rand.Int63n(1<<30)+1), rand.Intn(7))
+			r, err := d.Sqrt(scale)
+			Ω(err).Should(Succeed())
+
+			f := new(big.Float).SetPrec(256).SetRat(toRat(d))
+			f.Sqrt(f)
+			exp, _ := f.Rat(nil)
+			Ω(r.String()).Should(Equal(exp.FloatString(scale)), "sqrt(%s)", d)
+		}
+	})
+
+	DescribeTable("Exp", func(x string, scale int, exp string) {
+		Ω(toDecimal(x).Exp(scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("zero", "0", 2, "1.00"),
+		Entry("e", "1", 18, "2.718281828459045235"),
+		Entry("negative", "-1", 18, "0.367879441171442322"),
+		Entry("ten", "10", 12, "22026.465794806717"),
+		Entry("large", "43.5", 0, "7794889495725306400"),
+		Entry("very small", "-43", 18, "0.000000000000000000"),
+		Entry("too small", "-100", 2, "0.00"),
+	)
+
+	It("Exp overflow", func() {
+		_, err := toDecimal("44.1").Exp(0)
+		Ω(err).Should(Equal(decimal.ErrOverflow))
+
+		_, err = toDecimal("43.5").Exp(1)
+		Ω(err).Should(Equal(decimal.ErrOverflow))
+	})
+
+	DescribeTable("Ln", func(x string, scale int, exp string) {
+		Ω(toDecimal(x).Ln(scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("one", "1", 2, "0.00"),
+		Entry("two", "2", 18, "0.693147180559945309"),
+		Entry("ten", "10", 18, "2.302585092994045684"),
+		Entry("half", "0.5", 18, "-0.693147180559945309"),
+		Entry("near one", "1.000000001", 18, "0.000000001000000000"),
+		Entry("large", "12345.678", 12, "9.421061321292"),
+	)
+
+	It("Ln non-positive", func() {
+		_, err := toDecimal("0").Ln(2)
+		Ω(err).Should(MatchError("[decimal] logarithm of non-positive number 0"))
+	})
+
+	It("Ln of Exp", func() {
+		for i := 0; i < 50; i++ {
+			x := decimal.FromInt(rand.Int63n(1000000)-500000).DivToScale(decimal.FromInt(100000), 5)
+			e, err := x.Exp(12)
+			Ω(err).Should(Succeed())
+			Ω(e.Ln(5)).Should(Equal(x), "ln(exp(%s))", x)
+		}
+	})
+
+	DescribeTable("roundApprox", func(v string, scale int, exp string, iterations int) {
+		n := 0
+		r, err := decimal.RoundApprox(scale, func(prec uint) *big.Float {
+			n++
+			f, _, err := big.ParseFloat(v, 10, prec, big.ToNearestEven)
+			Ω(err).Should(Succeed())
+			return f
+		})
+		Ω(err).Should(Succeed())
+		Ω(r).Should(Equal(toDecimal(exp)))
+		Ω(n).Should(Equal(iterations))
+	},
+		Entry("clear", "0.123", 2, "0.12", 1),
+		Entry("clear negative", "-0.126", 2, "-0.13", 1),
+		Entry("near half below", "0.124"+strings.Repeat("9", 40), 2, "0.12", 3),
+		Entry("near half above", "0.125"+strings.Repeat("0", 39)+"1", 2, "0.13", 3),
+		Entry("exact half", "0.125", 2, "0.13", 5),
+		Entry("exact negative half", "-0.125", 2, "-0.13", 5),
+	)
+})
@@ -0,0 +1,158 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// MsgpackExtType is the MessagePack extension type of Decimal, change it
+// before encoding or decoding if conflicts with other extension types.
+var MsgpackExtType int8 = 1
+
+const msgpackNil = 0xc0
+
+// MarshalMsgpack implement msgpack.Marshaler interface, encoded as extension
+// of MsgpackExtType, data is the same as MarshalBinary().
+func (d Decimal) MarshalMsgpack() ([]byte, error) {
+	var data [binary.MaxVarintLen64 + 1]byte
+	n := binary.PutVarint(data[:], d.digits)
+	data[n] = d.scale
+	n++
+
+	buf := make([]byte, 0, n+3)
+	switch n {
+	case 2:
+		buf = append(buf, 0xd5)
+	case 4:
+		buf = append(buf, 0xd6)
+	case 8:
+		buf = append(buf, 0xd7)
+	default:
+		buf = append(buf, 0xc7, byte(n))
+	}
+	buf = append(buf, byte(MsgpackExtType))
+	return append(buf, data[:n]...), nil
+}
+
+// UnmarshalMsgpack implement msgpack.Unmarshaler interface, decode extension
+// encoded by MarshalMsgpack(). Also accepts integer, and string parsed by
+// FromString(), so that values stored before as string can be decoded.
+func (d *Decimal) UnmarshalMsgpack(b []byte) error {
+	if len(b) == 0 {
+		return fmt.Errorf("[%s] empty msgpack data", tag)
+	}
+
+	c, rest := b[0], b[1:]
+	if isMsgpackInt(c) && len(rest) != 1<<(c&3) {
+		return fmt.Errorf("[%s] invalid msgpack integer %x", tag, b)
+	}
+	switch {
+	case c <= 0x7f: // positive fixint
+		*d = FromInt(int64(c))
+		return nil
+	case c >= 0xe0: // negative fixint
+		*d = FromInt(int64(int8(c)))
+		return nil
+	case c >= 0xa0 && c <= 0xbf: // fixstr
+		return d.unmarshalMsgpackStr(rest, int(c&0x1f), b)
+	}
+
+	switch c {
+	case 0xcc, 0xcd, 0xce, 0xcf: // uint 8, 16, 32, 64
+		v, ok := msgpackUint(rest, 1<<(c-0xcc))
+		if !ok || v > math.MaxInt64 {
+			return fmt.Errorf("[%s] invalid msgpack integer %x", tag, b)
+		}
+		*d = FromInt(int64(v))
+		return nil
+	case 0xd0, 0xd1, 0xd2, 0xd3: // int 8, 16, 32, 64
+		n := 1 << (c - 0xd0)
+		v, ok := msgpackUint(rest, n)
+		if !ok {
+			return fmt.Errorf("[%s] invalid msgpack integer %x", tag, b)
+		}
+		// sign extend
+		shift := uint(64 - 8*n)
+		*d = FromInt(int64(v<<shift) >> shift)
+		return nil
+	case 0xd9, 0xda, 0xdb: // str 8, 16, 32
+		size := 1 << (c - 0xd9)
+		n, ok := msgpackUint(rest, size)
+		if !ok {
+			return fmt.Errorf("[%s] invalid msgpack string %x", tag, b)
+		}
+		return d.unmarshalMsgpackStr(rest[size:], int(n), b)
+	case 0xd5, 0xd6, 0xd7: // fixext 2, 4, 8
+		return d.unmarshalMsgpackExt(rest, 1<<(c-0xd4), b)
+	case 0xc7: // ext 8
+		if len(rest) == 0 {
+			break
+		}
+		return d.unmarshalMsgpackExt(rest[1:], int(rest[0]), b)
+	}
+	return fmt.Errorf("[%s] can not decode msgpack %x to Decimal", tag, b)
+}
+
+// isMsgpackInt returns true if c is code of 8, 16, 32 or 64 bits integer.
+func isMsgpackInt(c byte) bool {
+	return c >= 0xcc && c <= 0xd3
+}
+
+func (d *Decimal) unmarshalMsgpackStr(s []byte, n int, b []byte) error {
+	if len(s) != n {
+		return fmt.Errorf("[%s] invalid msgpack string %x", tag, b)
+	}
+	v, err := ParseBytes(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func (d *Decimal) unmarshalMsgpackExt(ext []byte, n int, b []byte) error {
+	if len(ext) != n+1 {
+		return fmt.Errorf("[%s] invalid msgpack extension %x", tag, b)
+	}
+	if int8(ext[0]) != MsgpackExtType {
+		return fmt.Errorf("[%s] unexpected msgpack extension type %d", tag, int8(ext[0]))
+	}
+	return d.UnmarshalBinary(ext[1:])
+}
+
+// msgpackUint reads n bytes big endian unsigned integer from the start of b,
+// ok is false if b too short.
+func msgpackUint(b []byte, n int) (v uint64, ok bool) {
+	if len(b) < n {
+		return 0, false
+	}
+	for _, c := range b[:n] {
+		v = v<<8 | uint64(c)
+	}
+	return v, true
+}
+
+// MarshalMsgpack implement msgpack.Marshaler interface, NULL encoded as nil.
+func (d NullDecimal) MarshalMsgpack() ([]byte, error) {
+	if !d.Valid {
+		return []byte{msgpackNil}, nil
+	}
+	return d.Decimal.MarshalMsgpack()
+}
+
+// UnmarshalMsgpack implement msgpack.Unmarshaler interface, nil decoded to
+// NULL.
+func (d *NullDecimal) UnmarshalMsgpack(b []byte) error {
+	if len(b) == 1 && b[0] == msgpackNil {
+		d.Valid = false
+		d.Decimal = Zero(0)
+		return nil
+	}
+
+	if err := d.Decimal.UnmarshalMsgpack(b); err != nil {
+		return err
+	}
+	d.Valid = true
+	return nil
+}
@@ -0,0 +1,123 @@
+package decimal_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/testing/matcher"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var _ = Describe("MessagePack", func() {
+	DescribeTable("ext", func(s string, exp []byte) {
+		var d, back decimal.Decimal
+		Ω(decimal.FromString(s)).Should(matcher.Save(&d))
+		Ω(d.MarshalMsgpack()).Should(Equal(exp))
+
+		Ω(back.UnmarshalMsgpack(exp)).Should(Succeed())
+		Ω(back).Should(Equal(d))
+	},
+		Entry("zero", "0", []byte{0xd5, 0x01, 0x00, 0x00}),
+		Entry("-1", "-1", []byte{0xd5, 0x01, 0x01, 0x00}),
+		Entry("1.5", "1.5", []byte{0xd5, 0x01, 0x1e, 0x01}),
+		Entry("1.50", "1.50", []byte{0xc7, 0x03, 0x01, 0xac, 0x02, 0x02}),
+		Entry("fixext 4", "8192", []byte{0xd6, 0x01, 0x80, 0x80, 0x01, 0x00}),
+		Entry("fixext 8", "2199023255552", []byte{0xd7, 0x01, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01, 0x00}),
+		Entry("max int64", "9223372036854775807",
+			[]byte{0xc7, 0x0b, 0x01, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x00}),
+	)
+
+	DescribeTable("UnmarshalMsgpack other types", func(b []byte, exp string) {
+		var d decimal.Decimal
+		Ω(d.UnmarshalMsgpack(b)).Should(Succeed())
+		Ω(d.String()).Should(Equal(exp))
+	},
+		Entry("positive fixint", []byte{0x05}, "5"),
+		Entry("negative fixint", []byte{0xff}, "-1"),
+		Entry("uint8", []byte{0xcc, 0xff}, "255"),
+		Entry("uint64", []byte{0xcf, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "9223372036854775807"),
+		Entry("int8", []byte{0xd0, 0x80}, "-128"),
+		Entry("int16", []byte{0xd1, 0xff, 0x00}, "-256"),
+		Entry("int64", []byte{0xd3, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, "-9223372036854775808"),
+		Entry("fixstr", []byte{0xa4, '1', '.', '5', '0'}, "1.50"),
+		Entry("str8", []byte{0xd9, 0x04, '1', '.', '5', '0'}, "1.50"),
+	)
+
+	DescribeTable("UnmarshalMsgpack error", func(b []byte, exp interface{}) {
+		var d decimal.Decimal
+		Ω(d.UnmarshalMsgpack(b)).Should(MatchError(exp))
+	},
+		Entry("empty", []byte{}, "[decimal] empty msgpack data"),
+		Entry("nil", []byte{0xc0}, "[decimal] can not decode msgpack c0 to Decimal"),
+		Entry("float", []byte{0xca, 0x3f, 0xc0, 0x00, 0x00}, "[decimal] can not decode msgpack ca3fc00000 to Decimal"),
+		Entry("uint64 overflow", []byte{0xcf, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			"[decimal] invalid msgpack integer cf8000000000000000"),
+		Entry("short integer", []byte{0xd1, 0xff}, "[decimal] invalid msgpack integer d1ff"),
+		Entry("short string", []byte{0xa4, '1'}, "[decimal] invalid msgpack string a431"),
+		Entry("not a number", []byte{0xa1, 'x'}, `[decimal] "x" not a number, unexpected 'x' at position 0`),
+		Entry("ext type", []byte{0xd5, 0x02, 0x00, 0x00}, "[decimal] unexpected msgpack extension type 2"),
+		Entry("ext length", []byte{0xc7, 0x03, 0x01, 0x00, 0x00}, "[decimal] invalid msgpack extension c703010000"),
+		Entry("ext scale", []byte{0xd5, 0x01, 0x00, 0x13}, "[decimal] scale 19 out of range"),
+	)
+
+	It("NullDecimal", func() {
+		Ω(decimal.NullDecimal{}.MarshalMsgpack()).Should(Equal([]byte{0xc0}))
+		d := decimal.NullDecimal{Decimal: decimal.New(15, 1), Valid: true}
+		Ω(d.MarshalMsgpack()).Should(Equal([]byte{0xd5, 0x01, 0x1e, 0x01}))
+
+		var back decimal.NullDecimal
+		Ω(back.UnmarshalMsgpack([]byte{0xd5, 0x01, 0x1e, 0x01})).Should(Succeed())
+		Ω(back).Should(Equal(d))
+		Ω(back.UnmarshalMsgpack([]byte{0xc0})).Should(Succeed())
+		Ω(back).Should(Equal(decimal.NullDecimal{}))
+		Ω(back.UnmarshalMsgpack([]byte{0xc1})).ShouldNot(Succeed())
+	})
+
+	Context("interop", func() {
+		type order struct {
+			Amount decimal.Decimal
+			Tax    decimal.NullDecimal
+			Refund decimal.NullDecimal
+		}
+
+		It("round trip", func() {
+			o := order{
+				Amount: decimal.New(-12345, 2),
+				Tax:    decimal.NullDecimal{Decimal: decimal.New(100, 3), Valid: true},
+			}
+			b, err := msgpack.Marshal(o)
+			Ω(err).Should(Succeed())
+			var back order
+			Ω(msgpack.Unmarshal(b, &back)).Should(Succeed())
+			Ω(back).Should(Equal(o))
+		})
+
+		It("decode string and number", func() {
+			b, err := msgpack.Marshal(map[string]interface{}{"Amount": "1.50", "Tax": 3, "Refund": nil})
+			Ω(err).Should(Succeed())
+			back := order{Refund: decimal.NullDecimal{Valid: true}}
+			Ω(msgpack.Unmarshal(b, &back)).Should(Succeed())
+			Ω(back).Should(Equal(order{
+				Amount: decimal.New(150, 2),
+				Tax:    decimal.NullDecimal{Decimal: decimal.FromInt(3), Valid: true},
+			}))
+		})
+
+		It("as extension", func() {
+			b, err := decimal.New(150, 2).MarshalMsgpack()
+			Ω(err).Should(Succeed())
+			var raw msgpack.RawMessage
+			Ω(msgpack.Unmarshal(b, &raw)).Should(Succeed())
+			Ω([]byte(raw)).Should(Equal(b))
+
+			dec := msgpack.NewDecoder(bytes.NewReader(b))
+			id, n, err := dec.DecodeExtHeader()
+			Ω(err).Should(Succeed())
+			Ω(id).Should(Equal(decimal.MsgpackExtType))
+			Ω(n).Should(Equal(3))
+		})
+	})
+})
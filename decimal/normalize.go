@@ -0,0 +1,72 @@
+package decimal
+
+import "fmt"
+
+// ErrPrecisionLoss indicates that a value can not be represented exactly in
+// the requested scale.
+var ErrPrecisionLoss = fmt.Errorf("[%s] loses precision", tag)
+
+// Normalize strips trailing zeros to the minimal scale, such as 1.50 to 1.5,
+// 1.00 to 1, zero of any scale to 0. Numerically equal values have the same
+// normalized value, compare by == or use as map keys.
+func (d Decimal) Normalize() Decimal {
+	digits, scale := d.digits, d.scale
+	if digits == 0 {
+		return Decimal{}
+	}
+	for scale > 0 && digits%10 == 0 {
+		digits /= 10
+		scale--
+	}
+	return Decimal{digits, scale}
+}
+
+// Rescale returns the same value in specific scale, such as 1.50 to scale 1
+// is 1.5, to scale 3 is 1.500. Unlike Round(), returns ErrPrecisionLoss if
+// the value can not be represented exactly, such as 1.55 to scale 1, and
+// ErrOverflow if digits out of int64 range.
+func (d Decimal) Rescale(scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+
+	diff := scale - int(d.scale)
+	switch {
+	case diff > 0:
+		digits, ok := mulPow10(d.digits, diff)
+		if !ok {
+			return Decimal{}, ErrOverflow
+		}
+		return Decimal{digits, uint8(scale)}, nil
+	case diff < 0:
+		p := powerOf10(-diff)
+		if d.digits%p != 0 {
+			return Decimal{}, ErrPrecisionLoss
+		}
+		return Decimal{d.digits / p, uint8(scale)}, nil
+	default:
+		return d, nil
+	}
+}
+
+// Canonical returns the string of normalized value, such as "1.5" for 1.50,
+// same for numerically equal values. Use it as map keys or to compare values
+// stored as text.
+func (d Decimal) Canonical() string {
+	return d.Normalize().String()
+}
+
+// Hash returns hash code of the value, same for numerically equal values,
+// such as 1.5 and 1.50.
+func (d Decimal) Hash() uint64 {
+	n := d.Normalize()
+
+	// splitmix64 finalizer
+	h := uint64(n.digits) + uint64(n.scale)*0x9e3779b97f4a7c15
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
@@ -0,0 +1,74 @@
+package decimal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+)
+
+var _ = Describe("Normalize", func() {
+	DescribeTable("Normalize", func(s, exp string) {
+		d := toDecimal(s).Normalize()
+		Ω(d.String()).Should(Equal(exp))
+		Ω(d).Should(Equal(toDecimal(exp)))
+	},
+		Entry("no trailing zero", "1.5", "1.5"),
+		Entry("trailing zeros", "1.500", "1.5"),
+		Entry("integer", "100.00", "100"),
+		Entry("integer no scale", "100", "100"),
+		Entry("zero", "0.000", "0"),
+		Entry("negative", "-0.10", "-0.1"),
+		Entry("max scale", "0.000000000000000010", "0.00000000000000001"),
+	)
+
+	DescribeTable("Rescale", func(s string, scale int, exp string) {
+		Ω(toDecimal(s).Rescale(scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("same", "1.50", 2, "1.50"),
+		Entry("up", "1.5", 3, "1.500"),
+		Entry("down", "1.50", 1, "1.5"),
+		Entry("down to integer", "-100.00", 0, "-100"),
+		Entry("zero", "0.00", 18, "0.000000000000000000"),
+	)
+
+	DescribeTable("Rescale error", func(s string, scale int, exp string) {
+		_, err := toDecimal(s).Rescale(scale)
+		Ω(err).Should(MatchError(exp))
+	},
+		Entry("precision loss", "1.55", 1, "[decimal] loses precision"),
+		Entry("overflow", "10", 18, "[decimal] value out of range"),
+		Entry("scale out of range", "1", 19, "[decimal] scale 19 out of range"),
+	)
+
+	It("Rescale sentinel errors", func() {
+		_, err := toDecimal("1.55").Rescale(0)
+		Ω(err).Should(Equal(decimal.ErrPrecisionLoss))
+	})
+
+	It("Canonical", func() {
+		Ω(toDecimal("1.50").Canonical()).Should(Equal("1.5"))
+		Ω(toDecimal("0.00").Canonical()).Should(Equal("0"))
+
+		m := map[string]int{}
+		m[toDecimal("1.0").Canonical()]++
+		m[toDecimal("1.00").Canonical()]++
+		m[toDecimal("1").Canonical()]++
+		Ω(m).Should(Equal(map[string]int{"1": 3}))
+	})
+
+	It("normalized as map key", func() {
+		m := map[decimal.Decimal]int{}
+		m[toDecimal("1.0").Normalize()]++
+		m[toDecimal("1.00").Normalize()]++
+		Ω(m).Should(HaveLen(1))
+	})
+
+	It("Hash", func() {
+		Ω(toDecimal("1.5").Hash()).Should(Equal(toDecimal("1.500").Hash()))
+		Ω(toDecimal("0").Hash()).Should(Equal(toDecimal("0.00").Hash()))
+		Ω(toDecimal("1.5").Hash()).ShouldNot(Equal(toDecimal("15").Hash()))
+		Ω(toDecimal("1.5").Hash()).ShouldNot(Equal(toDecimal("-1.5").Hash()))
+		Ω(toDecimal("0.1").Hash()).ShouldNot(Equal(toDecimal("1").Hash()))
+	})
+})
@@ -3,6 +3,7 @@ package decimal
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding"
 	"encoding/json"
 
 	"gopkg.in/mgo.v2/bson"
@@ -78,9 +79,64 @@ func (d *NullDecimal) UnmarshalJSON(buf []byte) error {
 	return nil
 }
 
+// MarshalText implement encoding.TextMarshaler interface, NULL marshaled to
+// empty text.
+func (d NullDecimal) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return d.Decimal.MarshalText()
+}
+
+// UnmarshalText implement encoding.TextUnmarshaler interface, empty text
+// unmarshaled to NULL.
+func (d *NullDecimal) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		d.Valid = false
+		d.Decimal = Zero(0)
+		return nil
+	}
+
+	if err := d.Decimal.UnmarshalText(text); err != nil {
+		return err
+	}
+	d.Valid = true
+	return nil
+}
+
+// MarshalBinary implement encoding.BinaryMarshaler interface, NULL marshaled to
+// empty data.
+func (d NullDecimal) MarshalBinary() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return d.Decimal.MarshalBinary()
+}
+
+// UnmarshalBinary implement encoding.BinaryUnmarshaler interface, empty data
+// unmarshaled to NULL.
+func (d *NullDecimal) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		d.Valid = false
+		d.Decimal = Zero(0)
+		return nil
+	}
+
+	if err := d.Decimal.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	d.Valid = true
+	return nil
+}
+
 var (
 	_ bson.Getter      = NullDecimal{}
 	_ bson.Setter      = &NullDecimal{}
 	_ json.Marshaler   = NullDecimal{}
 	_ json.Unmarshaler = &NullDecimal{}
+
+	_ encoding.TextMarshaler     = NullDecimal{}
+	_ encoding.TextUnmarshaler   = &NullDecimal{}
+	_ encoding.BinaryMarshaler   = NullDecimal{}
+	_ encoding.BinaryUnmarshaler = &NullDecimal{}
 )
@@ -65,6 +65,35 @@ var _ = Describe("NullDecimal", func() {
 		Ω(v).Should(Equal(NullDecimal{Zero(0), false}))
 	})
 
+	It("Text marshal", func() {
+		d, err := FromString("3.30")
+		Ω(err).Should(Succeed())
+		Ω(NullDecimal{d, true}.MarshalText()).Should(BeEquivalentTo("3.30"))
+		Ω(NullDecimal{d, false}.MarshalText()).Should(BeEquivalentTo(""))
+
+		v := NullDecimal{FromInt(100), false}
+		Ω(v.UnmarshalText([]byte("3.30"))).Should(Succeed())
+		Ω(v).Should(Equal(NullDecimal{d, true}))
+
+		Ω(v.UnmarshalText([]byte{})).Should(Succeed())
+		Ω(v).Should(Equal(NullDecimal{Zero(0), false}))
+	})
+
+	It("Binary marshal", func() {
+		d, err := FromString("3.30")
+		Ω(err).Should(Succeed())
+		Ω(NullDecimal{d, false}.MarshalBinary()).Should(BeEmpty())
+
+		buf, err := NullDecimal{d, true}.MarshalBinary()
+		Ω(err).Should(Succeed())
+		v := NullDecimal{}
+		Ω(v.UnmarshalBinary(buf)).Should(Succeed())
+		Ω(v).Should(Equal(NullDecimal{d, true}))
+
+		Ω(v.UnmarshalBinary(nil)).Should(Succeed())
+		Ω(v).Should(Equal(NullDecimal{Zero(0), false}))
+	})
+
 })
 
 // marshal value to bson, then marshal back.
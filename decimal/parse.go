@@ -0,0 +1,164 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+var (
+	// ErrSyntax indicates that a string does not have decimal number syntax.
+	ErrSyntax = errors.New("not a number")
+
+	// ErrRange indicates that effective number of a string out of int64 range.
+	ErrRange = errors.New("effective number out of range")
+)
+
+// ParseError records a failed conversion from string to Decimal.
+type ParseError struct {
+	Str string // the input string
+	Pos int    // byte offset of the offending character, len(Str) if unexpected end
+	Err error  // the reason conversion failed, ErrSyntax or ErrRange
+}
+
+func (e *ParseError) Error() string {
+	if e.Err == ErrRange {
+		return fmt.Sprintf("[%s] \"%s\" %s at position %d", tag, e.Str, e.Err, e.Pos)
+	}
+
+	if e.Pos >= len(e.Str) {
+		return fmt.Sprintf("[%s] \"%s\" %s, unexpected end", tag, e.Str, e.Err)
+	}
+	r, _ := utf8.DecodeRuneInString(e.Str[e.Pos:])
+	return fmt.Sprintf("[%s] \"%s\" %s, unexpected %q at position %d", tag, e.Str, e.Err, r, e.Pos)
+}
+
+// Unwrap returns the reason of ParseError, ErrSyntax or ErrRange.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parse decimal number in string, in format:
+//
+//	[whitespaces][+|-][digits][.digits][(e|E)[+|-]digits][whitespaces]
+//
+// at least one digit required before exponent. Returns digits and scale of the
+// number, digits multiplied if exponent greater than fragment digits, trailing
+// zeros beyond MaxScale dropped. If failed,
+// returns the offset of offending character and ErrSyntax or ErrRange.
+//
+// parse() never keeps a reference of s, so s not escape to heap.
+func parse(s string) (digits int64, scale int, pos int, err error) {
+	i, end := 0, len(s)
+	for i < end && isSpace(s[i]) {
+		i++
+	}
+	for end > i && isSpace(s[end-1]) {
+		end--
+	}
+
+	neg := false
+	if i < end && (s[i] == '+' || s[i] == '-') {
+		neg = s[i] == '-'
+		i++
+	}
+
+	limit := uint64(maxVal)
+	if neg {
+		limit++
+	}
+
+	var u uint64
+	nDigits, frac, dot := 0, 0, false
+	for ; i < end; i++ {
+		c := s[i]
+		if c == '.' {
+			if dot {
+				return 0, 0, i, ErrSyntax
+			}
+			dot = true
+			continue
+		}
+		if c < '0' || c > '9' {
+			break
+		}
+
+		d := uint64(c - '0')
+		if u > (limit-d)/10 {
+			return 0, 0, i, ErrRange
+		}
+		u = u*10 + d
+		nDigits++
+		if dot {
+			frac++
+		}
+	}
+	if nDigits == 0 {
+		return 0, 0, i, ErrSyntax
+	}
+
+	exp, expPos := 0, i
+	if i < end && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		expNeg := false
+		if i < end && (s[i] == '+' || s[i] == '-') {
+			expNeg = s[i] == '-'
+			i++
+		}
+
+		start := i
+		for ; i < end && s[i] >= '0' && s[i] <= '9'; i++ {
+			if exp < 10000 {
+				exp = exp*10 + int(s[i]-'0')
+			}
+		}
+		if i == start {
+			return 0, 0, i, ErrSyntax
+		}
+		if expNeg {
+			exp = -exp
+		}
+	}
+	if i != end {
+		return 0, 0, i, ErrSyntax
+	}
+
+	digits = int64(u)
+	if neg {
+		digits = -digits
+	}
+
+	scale = frac - exp
+	if scale < 0 {
+		if -scale >= len(tenth) {
+			if digits != 0 {
+				return 0, 0, expPos, ErrRange
+			}
+			return 0, 0, 0, nil
+		}
+		var ok bool
+		if digits, ok = mulPow10(digits, -scale); !ok {
+			return 0, 0, expPos, ErrRange
+		}
+		scale = 0
+	}
+	if scale > MaxScale {
+		// drop trailing zeros beyond MaxScale, such as "1.0e-18", value not
+		// changed.
+		if digits == 0 {
+			scale = MaxScale
+		}
+		for ; scale > MaxScale && digits%10 == 0; scale-- {
+			digits /= 10
+		}
+	}
+	return digits, scale, 0, nil
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+	return false
+}
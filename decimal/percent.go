@@ -0,0 +1,166 @@
+package decimal
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Percent is a percentage, Percent(FromInt(5)) is 5%, ratio 0.05. Convert
+// between Percent and Decimal of the same number by type conversion, use
+// Ratio() and PercentFromRatio() to convert from/to the ratio.
+type Percent Decimal
+
+// BasisPoints is a value in basis points, one basis point is 0.01%, ratio
+// 0.0001. Convert between BasisPoints and Decimal of the same number by type
+// conversion.
+type BasisPoints Decimal
+
+// PercentFromString parse percentage string, such as "12.5%" or "12.5", "%"
+// suffix is optional.
+func PercentFromString(s string) (Percent, error) {
+	d, err := FromString(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	return Percent(d), err
+}
+
+// PercentFromRatio returns ratio as percentage, such as 0.125 to 12.5%.
+// Scale of result is two less than ratio, but not less than zero. Panics with
+// ErrOverflow if out of range.
+func PercentFromRatio(r Decimal) Percent {
+	return Percent(fromRatio(r, 2))
+}
+
+// String returns percentage with "%" suffix, such as "12.5%".
+func (p Percent) String() string {
+	return Decimal(p).String() + "%"
+}
+
+// Ratio returns ratio of percentage, such as 12.5% to 0.125. Scale of result
+// is two greater than percentage, round to MaxScale if exceeded.
+func (p Percent) Ratio() Decimal {
+	return toRatio(Decimal(p), 2)
+}
+
+// BasisPoints returns percentage in basis points, such as 1.25% to 125bp.
+// Panics with ErrOverflow if out of range.
+func (p Percent) BasisPoints() BasisPoints {
+	return BasisPoints(fromRatio(Decimal(p), 2))
+}
+
+// BasisPointsFromString parse basis points string, such as "25bp", "25bps" or
+// "25", suffix is optional.
+func BasisPointsFromString(s string) (BasisPoints, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "bps") {
+		s = s[:len(s)-1]
+	}
+	d, err := FromString(strings.TrimSuffix(s, "bp"))
+	return BasisPoints(d), err
+}
+
+// BasisPointsFromRatio returns ratio in basis points, such as 0.0025 to 25bp.
+// Scale of result is four less than ratio, but not less than zero. Panics
+// with ErrOverflow if out of range.
+func BasisPointsFromRatio(r Decimal) BasisPoints {
+	return BasisPoints(fromRatio(r, 4))
+}
+
+// String returns basis points with "bp" suffix, such as "25bp".
+func (b BasisPoints) String() string {
+	return Decimal(b).String() + "bp"
+}
+
+// Ratio returns ratio of basis points, such as 25bp to 0.0025. Scale of result
+// is four greater than basis points, round to MaxScale if exceeded.
+func (b BasisPoints) Ratio() Decimal {
+	return toRatio(Decimal(b), 4)
+}
+
+// Percent returns basis points as percentage, such as 125bp to 1.25%. Scale of
+// result is two greater than basis points, round to MaxScale if exceeded.
+func (b BasisPoints) Percent() Percent {
+	return Percent(toRatio(Decimal(b), 2))
+}
+
+// ApplyPercent returns d * p, such as 200 * 15% = 30, round to scale using
+// rounding mode. Returns ErrOverflow if result out of range.
+func (d Decimal) ApplyPercent(p Percent, scale int, mode RoundingMode) (Decimal, error) {
+	return d.mulRatio(big.NewInt(p.digits), int(p.scale)+2, scale, mode)
+}
+
+// Markup returns d * (1 + p), such as cost 200 markup 15% is 230, round to
+// scale using rounding mode. Returns ErrOverflow if result out of range.
+func (d Decimal) Markup(p Percent, scale int, mode RoundingMode) (Decimal, error) {
+	f := bigPowerOf10(int(p.scale) + 2)
+	return d.mulRatio(f.Add(f, big.NewInt(p.digits)), int(p.scale)+2, scale, mode)
+}
+
+// Discount returns d * (1 - p), such as price 200 discount 15% is 170, round
+// to scale using rounding mode. Returns ErrOverflow if result out of range.
+func (d Decimal) Discount(p Percent, scale int, mode RoundingMode) (Decimal, error) {
+	f := bigPowerOf10(int(p.scale) + 2)
+	return d.mulRatio(f.Sub(f, big.NewInt(p.digits)), int(p.scale)+2, scale, mode)
+}
+
+// PercentOf returns part / whole as percentage, such as 30 of 200 is 15%,
+// round to scale using rounding mode. Returns ErrDivisionByZero if whole is
+// zero, ErrOverflow if result out of range.
+func PercentOf(part, whole Decimal, scale int, mode RoundingMode) (Percent, error) {
+	if err := checkScale(scale); err != nil {
+		return Percent{}, err
+	}
+	if whole.digits == 0 {
+		return Percent{}, ErrDivisionByZero
+	}
+
+	num := big.NewInt(part.digits)
+	r, err := quoToScale(num.Mul(num, big.NewInt(100)), int(part.scale), big.NewInt(whole.digits), int(whole.scale), scale, mode)
+	return Percent(r), err
+}
+
+// PercentChange returns (to - from) / |from| as percentage, such as from 200
+// to 230 is 15%, from -200 to -230 is -15%, round to scale using rounding
+// mode. Returns ErrDivisionByZero if from is zero, ErrOverflow if result out of
+// range.
+func PercentChange(from, to Decimal, scale int, mode RoundingMode) (Percent, error) {
+	if err := checkScale(scale); err != nil {
+		return Percent{}, err
+	}
+	if from.digits == 0 {
+		return Percent{}, ErrDivisionByZero
+	}
+
+	s := max(from.scale, to.scale)
+	num := toBig(to, s)
+	num.Sub(num, toBig(from, s)).Mul(num, big.NewInt(100))
+	den := big.NewInt(from.digits)
+	r, err := quoToScale(num, s, den.Abs(den), int(from.scale), scale, mode)
+	return Percent(r), err
+}
+
+// mulRatio returns d * (f / 10^fScale) round to scale using rounding mode.
+func (d Decimal) mulRatio(f *big.Int, fScale, scale int, mode RoundingMode) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+
+	num := big.NewInt(d.digits)
+	return quoToScale(num.Mul(num, f), int(d.scale)+fScale, big.NewInt(1), 0, scale, mode)
+}
+
+// toRatio returns d / 10^n, round to MaxScale if scale exceeded.
+func toRatio(d Decimal, n int) Decimal {
+	scale := int(d.scale) + n
+	if scale <= MaxScale {
+		return Decimal{d.digits, uint8(scale)}
+	}
+	return Decimal{roundDivPow10(d.digits, scale-MaxScale), MaxScale}
+}
+
+// fromRatio returns d * 10^n, panics with ErrOverflow if out of range.
+func fromRatio(d Decimal, n int) Decimal {
+	if int(d.scale) >= n {
+		return Decimal{d.digits, d.scale - uint8(n)}
+	}
+	diff := n - int(d.scale)
+	return Decimal{mustMulPow10(d.digits, diff), 0}
+}
@@ -0,0 +1,127 @@
+package decimal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/testing/matcher"
+)
+
+var _ = Describe("Percent", func() {
+	toPercent := func(s string) (p decimal.Percent) {
+		Ω(decimal.PercentFromString(s)).Should(matcher.Save(&p))
+		return
+	}
+
+	DescribeTable("PercentFromString", func(s, exp string) {
+		Ω(toPercent(s).String()).Should(Equal(exp))
+	},
+		Entry("with suffix", "12.5%", "12.5%"),
+		Entry("without suffix", "12.5", "12.5%"),
+		Entry("spaces", " -3% ", "-3%"),
+	)
+
+	It("PercentFromString error", func() {
+		_, err := decimal.PercentFromString("12%%")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	DescribeTable("Ratio", func(p, exp string) {
+		Ω(toPercent(p).Ratio()).Should(Equal(toDecimal(exp)))
+	},
+		Entry("integer", "5", "0.05"),
+		Entry("fragment", "12.5", "0.125"),
+		Entry("round to max scale", "0.000000000000000125", "0.000000000000000001"),
+		Entry("round half up to max scale", "0.000000000000000150", "0.000000000000000002"),
+		Entry("max scale", "0.0000000000000001", "0.000000000000000001"),
+	)
+
+	DescribeTable("PercentFromRatio", func(r, exp string) {
+		Ω(decimal.PercentFromRatio(toDecimal(r)).String()).Should(Equal(exp))
+	},
+		Entry("fragment", "0.125", "12.5%"),
+		Entry("integer", "1", "100%"),
+		Entry("one scale", "0.5", "50%"),
+		Entry("two scale", "0.05", "5%"),
+	)
+
+	It("PercentFromRatio overflow", func() {
+		Ω(recoverPanic(func() {
+			decimal.PercentFromRatio(decimal.FromInt(1 << 62))
+		})).Should(Equal(decimal.ErrOverflow))
+	})
+
+	It("BasisPoints", func() {
+		Ω(toPercent("1.25").BasisPoints().String()).Should(Equal("125bp"))
+
+		var b decimal.BasisPoints
+		Ω(decimal.BasisPointsFromString("25bp")).Should(matcher.Save(&b))
+		Ω(b.Ratio()).Should(Equal(toDecimal("0.0025")))
+		Ω(b.Percent().String()).Should(Equal("0.25%"))
+		Ω(decimal.BasisPointsFromString("25bps")).Should(Equal(b))
+		Ω(decimal.BasisPointsFromString("25")).Should(Equal(b))
+		Ω(decimal.BasisPointsFromRatio(toDecimal("0.0025"))).Should(Equal(b))
+		Ω(decimal.BasisPointsFromRatio(toDecimal("0.01")).String()).Should(Equal("100bp"))
+
+		_, err := decimal.BasisPointsFromString("25s")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	DescribeTable("ApplyPercent", func(v, p string, scale int, mode decimal.RoundingMode, exp string) {
+		Ω(toDecimal(v).ApplyPercent(toPercent(p), scale, mode)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("integer", "200", "15", 2, decimal.HalfUp, "30.00"),
+		Entry("fragment", "19.99", "7.5", 2, decimal.HalfUp, "1.50"),
+		Entry("round down", "19.99", "7.5", 2, decimal.Down, "1.49"),
+		Entry("half even", "0.10", "5", 2, decimal.HalfEven, "0.00"),
+		Entry("half up", "0.10", "5", 2, decimal.HalfUp, "0.01"),
+		Entry("negative", "-19.99", "7.5", 2, decimal.Floor, "-1.50"),
+		Entry("high scale", "1", "0.0000000000000001", 18, decimal.HalfUp, "0.000000000000000001"),
+	)
+
+	DescribeTable("Markup and Discount", func(v, p string, markup, discount string) {
+		Ω(toDecimal(v).Markup(toPercent(p), 2, decimal.HalfUp)).Should(Equal(toDecimal(markup)))
+		Ω(toDecimal(v).Discount(toPercent(p), 2, decimal.HalfUp)).Should(Equal(toDecimal(discount)))
+	},
+		Entry("integer", "200", "15", "230.00", "170.00"),
+		Entry("fragment", "9.99", "12.5", "11.24", "8.74"),
+		Entry("zero", "9.99", "0", "9.99", "9.99"),
+		Entry("full discount", "9.99", "100", "19.98", "0.00"),
+	)
+
+	It("ApplyPercent overflow", func() {
+		_, err := decimal.FromInt(1<<62).ApplyPercent(toPercent("300"), 0, decimal.HalfUp)
+		Ω(err).Should(Equal(decimal.ErrOverflow))
+	})
+
+	DescribeTable("PercentOf", func(part, whole string, scale int, mode decimal.RoundingMode, exp string) {
+		Ω(decimal.PercentOf(toDecimal(part), toDecimal(whole), scale, mode)).Should(Equal(toPercent(exp)))
+	},
+		Entry("integer", "30", "200", 0, decimal.HalfUp, "15"),
+		Entry("scaled", "30", "200", 2, decimal.HalfUp, "15.00"),
+		Entry("repeating", "1", "3", 2, decimal.HalfUp, "33.33"),
+		Entry("repeating up", "2", "3", 2, decimal.Down, "66.66"),
+		Entry("fragment", "0.125", "0.5", 1, decimal.HalfUp, "25.0"),
+		Entry("negative", "-1", "3", 2, decimal.Floor, "-33.34"),
+	)
+
+	DescribeTable("PercentChange", func(from, to string, exp string) {
+		Ω(decimal.PercentChange(toDecimal(from), toDecimal(to), 2, decimal.HalfUp)).Should(Equal(toPercent(exp)))
+	},
+		Entry("increase", "200", "230", "15.00"),
+		Entry("decrease", "200", "170", "-15.00"),
+		Entry("negative decrease", "-200", "-230", "-15.00"),
+		Entry("negative increase", "-200", "-170", "15.00"),
+		Entry("mixed scale", "3", "3.5", "16.67"),
+		Entry("no change", "3.5", "3.50", "0.00"),
+	)
+
+	It("division by zero", func() {
+		_, err := decimal.PercentOf(decimal.FromInt(1), decimal.Zero(2), 2, decimal.HalfUp)
+		Ω(err).Should(Equal(decimal.ErrDivisionByZero))
+
+		_, err = decimal.PercentChange(decimal.Zero(2), decimal.FromInt(1), 2, decimal.HalfUp)
+		Ω(err).Should(Equal(decimal.ErrDivisionByZero))
+	})
+})
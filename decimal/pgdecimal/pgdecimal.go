@@ -0,0 +1,127 @@
+// Package pgdecimal implements pgtype encoders and decoders of
+// decimal.Decimal, to read and write PostgreSQL NUMERIC columns with pgx in
+// binary or text format.
+//
+// Wrap values in Numeric or NullNumeric to pass them as query arguments or
+// scan targets:
+//
+//	var price pgdecimal.Numeric
+//	err := conn.QueryRow(ctx, "select price from item").Scan(&price)
+package pgdecimal
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	"github.com/redforks/math/decimal"
+)
+
+const tag = "math-pgdecimal"
+
+// Numeric is decimal.Decimal stored as PostgreSQL NUMERIC, NULL not allowed.
+type Numeric struct {
+	decimal.Decimal
+}
+
+// EncodeBinary implement pgtype.BinaryEncoder interface, append value in
+// PostgreSQL NUMERIC binary format, dscale set to scale of the value.
+func (n Numeric) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	return n.Decimal.AppendPGNumeric(buf), nil
+}
+
+// DecodeBinary implement pgtype.BinaryDecoder interface, decode PostgreSQL
+// NUMERIC binary format, scale set to dscale. Returns error if src is NULL,
+// NaN or Infinity, or value out of Decimal range.
+func (n *Numeric) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		return fmt.Errorf("[%s] can not decode NULL numeric to Decimal", tag)
+	}
+	d, err := decimal.ParsePGNumeric(src)
+	if err != nil {
+		return err
+	}
+	n.Decimal = d
+	return nil
+}
+
+// EncodeText implement pgtype.TextEncoder interface, same format as String().
+func (n Numeric) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	return n.Decimal.AppendString(buf), nil
+}
+
+// DecodeText implement pgtype.TextDecoder interface, same as
+// decimal.FromString(). Returns error if src is NULL.
+func (n *Numeric) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		return fmt.Errorf("[%s] can not decode NULL numeric to Decimal", tag)
+	}
+	d, err := decimal.ParseBytes(src)
+	if err != nil {
+		return err
+	}
+	n.Decimal = d
+	return nil
+}
+
+// NullNumeric is decimal.NullDecimal stored as PostgreSQL NUMERIC.
+type NullNumeric struct {
+	decimal.NullDecimal
+}
+
+// EncodeBinary implement pgtype.BinaryEncoder interface, NULL encoded as nil.
+func (n NullNumeric) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return Numeric{n.Decimal}.EncodeBinary(ci, buf)
+}
+
+// DecodeBinary implement pgtype.BinaryDecoder interface, nil src decoded to
+// NULL.
+func (n *NullNumeric) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		n.NullDecimal = decimal.NullDecimal{Decimal: decimal.Zero(0)}
+		return nil
+	}
+
+	var v Numeric
+	if err := v.DecodeBinary(ci, src); err != nil {
+		return err
+	}
+	n.NullDecimal = decimal.NullDecimal{Decimal: v.Decimal, Valid: true}
+	return nil
+}
+
+// EncodeText implement pgtype.TextEncoder interface, NULL encoded as nil.
+func (n NullNumeric) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return Numeric{n.Decimal}.EncodeText(ci, buf)
+}
+
+// DecodeText implement pgtype.TextDecoder interface, nil src decoded to NULL.
+func (n *NullNumeric) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		n.NullDecimal = decimal.NullDecimal{Decimal: decimal.Zero(0)}
+		return nil
+	}
+
+	var v Numeric
+	if err := v.DecodeText(ci, src); err != nil {
+		return err
+	}
+	n.NullDecimal = decimal.NullDecimal{Decimal: v.Decimal, Valid: true}
+	return nil
+}
+
+var (
+	_ pgtype.BinaryEncoder = Numeric{}
+	_ pgtype.BinaryDecoder = &Numeric{}
+	_ pgtype.TextEncoder   = Numeric{}
+	_ pgtype.TextDecoder   = &Numeric{}
+	_ pgtype.BinaryEncoder = NullNumeric{}
+	_ pgtype.BinaryDecoder = &NullNumeric{}
+	_ pgtype.TextEncoder   = NullNumeric{}
+	_ pgtype.TextDecoder   = &NullNumeric{}
+)
@@ -0,0 +1,33 @@
+package pgdecimal_test
+
+import (
+	"encoding/hex"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPgdecimal(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pgdecimal Suite")
+}
+
+// unhex decodes space separated hex string, panics if s not valid hex.
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(strings.Replace(s, " ", "", -1))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// abs returns the absolute value of v.
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,86 @@
+package pgdecimal_test
+
+import (
+	"math/big"
+	"math/rand"
+
+	"github.com/jackc/pgtype"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/math/decimal/pgdecimal"
+)
+
+var _ = Describe("Pgdecimal", func() {
+	It("binary", func() {
+		n := pgdecimal.Numeric{decimal.New(150, 2)}
+		Ω(n.EncodeBinary(nil, []byte{1})).Should(Equal(unhex("01 0002 0000 0000 0002 0001 1388")))
+
+		var back pgdecimal.Numeric
+		Ω(back.DecodeBinary(nil, unhex("0002 0000 0000 0002 0001 1388"))).Should(Succeed())
+		Ω(back).Should(Equal(n))
+		Ω(back.DecodeBinary(nil, unhex("0000 0000 c000 0000"))).Should(MatchError("[decimal] can not decode numeric NaN to Decimal"))
+		Ω(back).Should(Equal(n))
+	})
+
+	It("NULL", func() {
+		var n pgdecimal.Numeric
+		Ω(n.DecodeBinary(nil, nil)).Should(MatchError("[math-pgdecimal] can not decode NULL numeric to Decimal"))
+		Ω(n.DecodeText(nil, nil)).Should(MatchError("[math-pgdecimal] can not decode NULL numeric to Decimal"))
+	})
+
+	It("text", func() {
+		var n pgdecimal.Numeric
+		Ω(pgdecimal.Numeric{decimal.New(-12345, 3)}.EncodeText(nil, []byte("x"))).Should(Equal([]byte("x-12.345")))
+		Ω(n.DecodeText(nil, []byte("1.50"))).Should(Succeed())
+		Ω(n.Decimal).Should(Equal(decimal.New(150, 2)))
+		Ω(n.DecodeText(nil, []byte("NaN"))).ShouldNot(Succeed())
+	})
+
+	It("NullNumeric", func() {
+		n := pgdecimal.NullNumeric{decimal.NullDecimal{Decimal: decimal.New(150, 2), Valid: true}}
+		Ω(pgdecimal.NullNumeric{}.EncodeBinary(nil, []byte{1})).Should(BeNil())
+		Ω(pgdecimal.NullNumeric{}.EncodeText(nil, []byte{1})).Should(BeNil())
+		Ω(n.EncodeBinary(nil, nil)).Should(Equal(unhex("0002 0000 0000 0002 0001 1388")))
+		Ω(n.EncodeText(nil, nil)).Should(Equal([]byte("1.50")))
+
+		var back pgdecimal.NullNumeric
+		Ω(back.DecodeBinary(nil, unhex("0002 0000 0000 0002 0001 1388"))).Should(Succeed())
+		Ω(back).Should(Equal(n))
+		Ω(back.DecodeBinary(nil, nil)).Should(Succeed())
+		Ω(back.Valid).Should(BeFalse())
+
+		Ω(back.DecodeText(nil, []byte("1.50"))).Should(Succeed())
+		Ω(back).Should(Equal(n))
+		Ω(back.DecodeText(nil, nil)).Should(Succeed())
+		Ω(back.Valid).Should(BeFalse())
+
+		Ω(back.DecodeBinary(nil, unhex("0000 0000 c000 0000"))).ShouldNot(Succeed())
+	})
+
+	It("interop with pgtype.Numeric", func() {
+		for i := 0; i < 1000; i++ {
+			d := decimal.New(rand.Int63()>>uint(rand.Intn(63))-rand.Int63()>>uint(rand.Intn(63)), rand.Intn(decimal.MaxScale+1))
+
+			buf, err := pgdecimal.Numeric{d}.EncodeBinary(nil, nil)
+			Ω(err).Should(Succeed())
+			var n pgtype.Numeric
+			Ω(n.DecodeBinary(nil, buf)).Should(Succeed())
+			exp := new(big.Rat).SetFrac(big.NewInt(d.Coefficient()), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale())), nil))
+			act := new(big.Rat).SetInt(n.Int)
+			pow := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(n.Exp))), nil))
+			if n.Exp < 0 {
+				act.Quo(act, pow)
+			} else {
+				act.Mul(act, pow)
+			}
+			Ω(act.Cmp(exp)).Should(Equal(0), d.String())
+
+			buf, err = n.EncodeBinary(nil, nil)
+			Ω(err).Should(Succeed())
+			var back pgdecimal.Numeric
+			Ω(back.DecodeBinary(nil, buf)).Should(Succeed())
+			Ω(back.Cmp(d)).Should(Equal(0), d.String())
+		}
+	})
+})
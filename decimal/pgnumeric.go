@@ -0,0 +1,139 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// PostgreSQL NUMERIC binary format: int16 ndigits, int16 weight, uint16 sign,
+// int16 dscale, followed by ndigits base-10000 uint16 digits, most significant
+// first. Value of the first digit is digit * 10000^weight.
+const (
+	pgNumericBase   = 10000
+	pgNumericPos    = 0x0000
+	pgNumericNeg    = 0x4000
+	pgNumericNaN    = 0xC000
+	pgNumericPosInf = 0xD000
+	pgNumericNegInf = 0xF000
+)
+
+// AppendPGNumeric appends d in PostgreSQL NUMERIC binary format to buf, dscale
+// set to scale of d.
+func (d Decimal) AppendPGNumeric(buf []byte) []byte {
+	// pad fraction to whole base-10000 digits, at most 22 decimal digits after
+	// padding, fits in 6 base-10000 digits.
+	fracDigits := (int(d.scale) + 3) / 4
+	u, _ := uint128{lo: absUint64(d.digits)}.mulPow10(fracDigits*4 - int(d.scale))
+
+	var digits [6]uint16
+	n, weight := 0, -fracDigits
+	for u != (uint128{}) {
+		var r uint64
+		u, r = u.quoRem(pgNumericBase)
+		if n == 0 && r == 0 {
+			// strip trailing zero digits
+			weight++
+			continue
+		}
+		digits[len(digits)-1-n] = uint16(r)
+		n++
+	}
+
+	sign := pgNumericPos
+	if d.digits < 0 {
+		sign = pgNumericNeg
+	}
+	if n == 0 {
+		weight = 0
+	} else {
+		weight += n - 1
+	}
+
+	buf = appendUint16(buf, uint16(n))
+	buf = appendUint16(buf, uint16(int16(weight)))
+	buf = appendUint16(buf, uint16(sign))
+	buf = appendUint16(buf, uint16(d.scale))
+	for _, digit := range digits[len(digits)-n:] {
+		buf = appendUint16(buf, digit)
+	}
+	return buf
+}
+
+// ParsePGNumeric decodes PostgreSQL NUMERIC binary format, scale set to
+// dscale. Returns error if src is NaN or Infinity, or value out of Decimal
+// range.
+func ParsePGNumeric(src []byte) (Decimal, error) {
+	if len(src) < 8 {
+		return Decimal{}, fmt.Errorf("[%s] invalid numeric binary data %x", tag, src)
+	}
+
+	n := int(int16(binary.BigEndian.Uint16(src)))
+	weight := int(int16(binary.BigEndian.Uint16(src[2:])))
+	sign := binary.BigEndian.Uint16(src[4:])
+	scale := int(int16(binary.BigEndian.Uint16(src[6:])))
+	if n < 0 || len(src) != 8+2*n {
+		return Decimal{}, fmt.Errorf("[%s] invalid numeric binary data %x", tag, src)
+	}
+
+	switch sign {
+	case pgNumericPos, pgNumericNeg:
+	case pgNumericNaN:
+		return Decimal{}, fmt.Errorf("[%s] can not decode numeric NaN to Decimal", tag)
+	case pgNumericPosInf:
+		return Decimal{}, fmt.Errorf("[%s] can not decode numeric Infinity to Decimal", tag)
+	case pgNumericNegInf:
+		return Decimal{}, fmt.Errorf("[%s] can not decode numeric -Infinity to Decimal", tag)
+	default:
+		return Decimal{}, fmt.Errorf("[%s] invalid numeric sign %#04x", tag, sign)
+	}
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+
+	var (
+		u  uint128
+		ok bool
+	)
+	for i := 0; i < n; i++ {
+		digit := binary.BigEndian.Uint16(src[8+2*i:])
+		if digit >= pgNumericBase {
+			return Decimal{}, fmt.Errorf("[%s] invalid numeric digit %d", tag, digit)
+		}
+		if u, ok = u.mul(pgNumericBase); !ok {
+			return Decimal{}, ErrOverflow
+		}
+		u = u.add(uint64(digit))
+	}
+
+	if u != (uint128{}) {
+		// u is the value in unit of the last digit, 10000^(weight-n+1), convert
+		// to unit of 10^-scale.
+		exp := 4*(weight-n+1) + scale
+		if exp >= 0 {
+			if u, ok = u.mulPow10(exp); !ok {
+				return Decimal{}, ErrOverflow
+			}
+		} else {
+			for exp = -exp; exp > 0; exp -= MaxScale {
+				p := exp
+				if p > MaxScale {
+					p = MaxScale
+				}
+				var r uint64
+				if u, r = u.quoRem(uint64(powerOf10(p))); r != 0 {
+					return Decimal{}, fmt.Errorf("[%s] numeric has more digits than dscale %d", tag, scale)
+				}
+			}
+		}
+	}
+
+	digits, ok := u.toInt64(sign == pgNumericNeg)
+	if !ok {
+		return Decimal{}, ErrOverflow
+	}
+	return Decimal{digits, uint8(scale)}, nil
+}
+
+func appendUint16(buf []byte, v uint16) []byte {
+	return append(buf, byte(v>>8), byte(v))
+}
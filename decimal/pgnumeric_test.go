@@ -0,0 +1,61 @@
+package decimal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/testing/matcher"
+)
+
+var _ = Describe("PostgreSQL numeric", func() {
+	// golden vectors: ndigits, weight, sign, dscale, base-10000 digits
+	DescribeTable("binary", func(s, golden string) {
+		var d decimal.Decimal
+		Ω(decimal.FromString(s)).Should(matcher.Save(&d))
+		Ω(d.AppendPGNumeric(nil)).Should(Equal(unhex(golden)))
+		Ω(decimal.ParsePGNumeric(unhex(golden))).Should(Equal(d))
+	},
+		Entry("0", "0", "0000 0000 0000 0000"),
+		Entry("0.00", "0.00", "0000 0000 0000 0002"),
+		Entry("1.5", "1.5", "0002 0000 0000 0001 0001 1388"),
+		Entry("1234.56", "1234.56", "0002 0000 0000 0002 04d2 15e0"),
+		Entry("-0.0001", "-0.0001", "0001 ffff 4000 0004 0001"),
+		Entry("10000", "10000", "0001 0001 0000 0000 0001"),
+		Entry("100000000", "100000000", "0001 0002 0000 0000 0001"),
+		Entry("12345678.9", "12345678.9", "0003 0001 0000 0001 04d2 162e 2328"),
+		Entry("1.00000001", "1.00000001", "0003 0000 0000 0008 0001 0000 0001"),
+		Entry("max scale", "0.000000000000000001", "0001 fffb 0000 0012 0064"),
+		Entry("max int64", "9223372036854775807", "0005 0004 0000 0000 039a 0d2c 0170 1565 16af"),
+		Entry("min int64", "-9223372036854775808", "0005 0004 4000 0000 039a 0d2c 0170 1565 16b0"),
+		Entry("max int64 at max scale", "9.223372036854775807", "0006 0000 0000 0012 0009 08b9 1c23 1ac6 1e4e 02bc"),
+	)
+
+	DescribeTable("ParsePGNumeric not normalized", func(golden, exp string) {
+		var d decimal.Decimal
+		Ω(decimal.ParsePGNumeric(unhex(golden))).Should(matcher.Save(&d))
+		Ω(d.String()).Should(Equal(exp))
+	},
+		Entry("trailing zero digit", "0002 0000 0000 0001 0001 0000", "1.0"),
+		Entry("leading zero digit", "0002 0001 0000 0000 0000 0001", "1"),
+		Entry("zero digits", "0002 0003 4000 0002 0000 0000", "0.00"),
+	)
+
+	DescribeTable("ParsePGNumeric error", func(golden, err string) {
+		_, e := decimal.ParsePGNumeric(unhex(golden))
+		Ω(e).Should(MatchError(err))
+	},
+		Entry("NaN", "0000 0000 c000 0000", "[decimal] can not decode numeric NaN to Decimal"),
+		Entry("Infinity", "0000 0000 d000 0000", "[decimal] can not decode numeric Infinity to Decimal"),
+		Entry("-Infinity", "0000 0000 f000 0000", "[decimal] can not decode numeric -Infinity to Decimal"),
+		Entry("bad sign", "0000 0000 1000 0000", "[decimal] invalid numeric sign 0x1000"),
+		Entry("scale", "0000 0000 0000 0013", "[decimal] scale 19 out of range"),
+		Entry("overflow", "0001 0005 0000 0000 0001", decimal.ErrOverflow.Error()),
+		Entry("overflow int64", "0005 0004 0000 0000 039a 0d2c 0170 1565 16b0", decimal.ErrOverflow.Error()),
+		Entry("more digits than dscale", "0001 ffff 0000 0002 0001", "[decimal] numeric has more digits than dscale 2"),
+		Entry("bad digit", "0001 0000 0000 0000 2710", "[decimal] invalid numeric digit 10000"),
+		Entry("short header", "0000 0000", "[decimal] invalid numeric binary data 00000000"),
+		Entry("length", "0002 0000 0000 0000 0001", "[decimal] invalid numeric binary data 00020000000000000001"),
+		Entry("empty", "", "[decimal] invalid numeric binary data "),
+	)
+})
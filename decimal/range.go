@@ -0,0 +1,231 @@
+package decimal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Bound is lower or upper bound of a Range.
+type Bound struct {
+	Value Decimal
+
+	// Open bound excludes Value.
+	Open bool
+
+	// Unbounded is negative infinity as lower bound, positive infinity as
+	// upper bound, Value and Open ignored.
+	Unbounded bool
+}
+
+// Range is an interval of decimal values, bounds can be open, closed or
+// unbounded. Zero value is [0, 0].
+type Range struct {
+	Lower, Upper Bound
+}
+
+// Closed returns range [min, max].
+func Closed(min, max Decimal) Range {
+	return Range{Bound{Value: min}, Bound{Value: max}}
+}
+
+// Open returns range (min, max).
+func Open(min, max Decimal) Range {
+	return Range{Bound{Value: min, Open: true}, Bound{Value: max, Open: true}}
+}
+
+// ClosedOpen returns range [min, max).
+func ClosedOpen(min, max Decimal) Range {
+	return Range{Bound{Value: min}, Bound{Value: max, Open: true}}
+}
+
+// OpenClosed returns range (min, max].
+func OpenClosed(min, max Decimal) Range {
+	return Range{Bound{Value: min, Open: true}, Bound{Value: max}}
+}
+
+// AtLeast returns range [min, +∞).
+func AtLeast(min Decimal) Range {
+	return Range{Bound{Value: min}, Bound{Unbounded: true}}
+}
+
+// GreaterThan returns range (min, +∞).
+func GreaterThan(min Decimal) Range {
+	return Range{Bound{Value: min, Open: true}, Bound{Unbounded: true}}
+}
+
+// AtMost returns range (-∞, max].
+func AtMost(max Decimal) Range {
+	return Range{Bound{Unbounded: true}, Bound{Value: max}}
+}
+
+// LessThan returns range (-∞, max).
+func LessThan(max Decimal) Range {
+	return Range{Bound{Unbounded: true}, Bound{Value: max, Open: true}}
+}
+
+// IsEmpty returns true if range contains no value, such as [2, 1] or [1, 1).
+func (r Range) IsEmpty() bool {
+	if r.Lower.Unbounded || r.Upper.Unbounded {
+		return false
+	}
+	c := r.Lower.Value.Cmp(r.Upper.Value)
+	return c > 0 || (c == 0 && (r.Lower.Open || r.Upper.Open))
+}
+
+// Contains returns true if v is in the range.
+func (r Range) Contains(v Decimal) bool {
+	return r.aboveLower(v) && r.belowUpper(v)
+}
+
+// Clamp returns v if it is in the range, otherwise the nearest bound value.
+// For open bound, the bound value returned is the limit of the range, not in
+// the range. Returns v if range is empty.
+func (r Range) Clamp(v Decimal) Decimal {
+	switch {
+	case r.IsEmpty():
+		return v
+	case !r.aboveLower(v):
+		return r.Lower.Value
+	case !r.belowUpper(v):
+		return r.Upper.Value
+	default:
+		return v
+	}
+}
+
+// Intersect returns values both in r and other, may be empty.
+func (r Range) Intersect(other Range) Range {
+	result := r
+	if cmpLower(other.Lower, r.Lower) > 0 {
+		result.Lower = other.Lower
+	}
+	if cmpUpper(other.Upper, r.Upper) < 0 {
+		result.Upper = other.Upper
+	}
+	return result
+}
+
+// Overlaps returns true if there is any value both in r and other.
+func (r Range) Overlaps(other Range) bool {
+	return !r.Intersect(other).IsEmpty()
+}
+
+// String returns range in interval notation, such as "[1.00, 2.00)",
+// "(-∞, 5]".
+func (r Range) String() string {
+	var b strings.Builder
+	switch {
+	case r.Lower.Unbounded:
+		b.WriteString("(-∞")
+	case r.Lower.Open:
+		b.WriteString("(" + r.Lower.Value.String())
+	default:
+		b.WriteString("[" + r.Lower.Value.String())
+	}
+	b.WriteString(", ")
+	switch {
+	case r.Upper.Unbounded:
+		b.WriteString("+∞)")
+	case r.Upper.Open:
+		b.WriteString(r.Upper.Value.String() + ")")
+	default:
+		b.WriteString(r.Upper.Value.String() + "]")
+	}
+	return b.String()
+}
+
+func (r Range) aboveLower(v Decimal) bool {
+	if r.Lower.Unbounded {
+		return true
+	}
+	c := v.Cmp(r.Lower.Value)
+	return c > 0 || (c == 0 && !r.Lower.Open)
+}
+
+func (r Range) belowUpper(v Decimal) bool {
+	if r.Upper.Unbounded {
+		return true
+	}
+	c := v.Cmp(r.Upper.Value)
+	return c < 0 || (c == 0 && !r.Upper.Open)
+}
+
+// cmpLower compares two lower bounds, the greater one contains less values.
+func cmpLower(a, b Bound) int {
+	switch {
+	case a.Unbounded && b.Unbounded:
+		return 0
+	case a.Unbounded:
+		return -1
+	case b.Unbounded:
+		return 1
+	}
+	if c := a.Value.Cmp(b.Value); c != 0 || a.Open == b.Open {
+		return c
+	}
+	if a.Open {
+		return 1
+	}
+	return -1
+}
+
+// cmpUpper compares two upper bounds, the less one contains less values.
+func cmpUpper(a, b Bound) int {
+	switch {
+	case a.Unbounded && b.Unbounded:
+		return 0
+	case a.Unbounded:
+		return 1
+	case b.Unbounded:
+		return -1
+	}
+	if c := a.Value.Cmp(b.Value); c != 0 || a.Open == b.Open {
+		return c
+	}
+	if a.Open {
+		return -1
+	}
+	return 1
+}
+
+// Tiers maps values to ranges, such as pricing tiers.
+type Tiers struct {
+	ranges []Range
+}
+
+// NewTiers creates Tiers of ranges, ranges must be non-empty, in ascending
+// order and not overlapping, gaps between ranges are allowed.
+func NewTiers(ranges ...Range) (*Tiers, error) {
+	for i, r := range ranges {
+		if r.IsEmpty() {
+			return nil, fmt.Errorf("[%s] tier %d %s is empty", tag, i, r)
+		}
+		if i > 0 && (ranges[i-1].Overlaps(r) || cmpLower(ranges[i-1].Lower, r.Lower) >= 0) {
+			return nil, fmt.Errorf("[%s] tier %d %s overlaps or before tier %d %s", tag, i, r, i-1, ranges[i-1])
+		}
+	}
+	return &Tiers{append([]Range(nil), ranges...)}, nil
+}
+
+// Lookup returns index of the tier contains v in O(log n), ok is false if no
+// tier contains v.
+func (t *Tiers) Lookup(v Decimal) (index int, ok bool) {
+	i := sort.Search(len(t.ranges), func(i int) bool {
+		return t.ranges[i].belowUpper(v)
+	})
+	if i < len(t.ranges) && t.ranges[i].Contains(v) {
+		return i, true
+	}
+	return -1, false
+}
+
+// Len returns number of tiers.
+func (t *Tiers) Len() int {
+	return len(t.ranges)
+}
+
+// Range returns range of tier i.
+func (t *Tiers) Range(i int) Range {
+	return t.ranges[i]
+}
@@ -0,0 +1,129 @@
+package decimal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+)
+
+var _ = Describe("Range", func() {
+	// mustDecimal is toDecimal() usable in table entries, evaluated before
+	// Gomega registered.
+	mustDecimal := func(s string) decimal.Decimal {
+		d, err := decimal.FromString(s)
+		if err != nil {
+			panic(err)
+		}
+		return d
+	}
+
+	one, two := decimal.FromInt(1), mustDecimal("2.00")
+
+	DescribeTable("Contains", func(r decimal.Range, v string, exp bool) {
+		Ω(r.Contains(mustDecimal(v))).Should(Equal(exp))
+	},
+		Entry("closed lower", decimal.Closed(one, two), "1", true),
+		Entry("closed upper", decimal.Closed(one, two), "2", true),
+		Entry("closed inside", decimal.Closed(one, two), "1.5", true),
+		Entry("closed below", decimal.Closed(one, two), "0.99", false),
+		Entry("closed above", decimal.Closed(one, two), "2.01", false),
+		Entry("open lower", decimal.Open(one, two), "1.000", false),
+		Entry("open upper", decimal.Open(one, two), "2", false),
+		Entry("open inside", decimal.Open(one, two), "1.001", true),
+		Entry("closed open lower", decimal.ClosedOpen(one, two), "1", true),
+		Entry("closed open upper", decimal.ClosedOpen(one, two), "2", false),
+		Entry("open closed lower", decimal.OpenClosed(one, two), "1", false),
+		Entry("open closed upper", decimal.OpenClosed(one, two), "2", true),
+		Entry("at least", decimal.AtLeast(one), "1", true),
+		Entry("at least huge", decimal.AtLeast(one), "9223372036854775807", true),
+		Entry("greater than", decimal.GreaterThan(one), "1", false),
+		Entry("at most", decimal.AtMost(one), "1", true),
+		Entry("at most small", decimal.AtMost(one), "-9223372036854775807", true),
+		Entry("less than", decimal.LessThan(one), "1", false),
+	)
+
+	DescribeTable("IsEmpty", func(r decimal.Range, exp bool) {
+		Ω(r.IsEmpty()).Should(Equal(exp))
+	},
+		Entry("closed", decimal.Closed(one, two), false),
+		Entry("single value", decimal.Closed(one, one), false),
+		Entry("half open single value", decimal.ClosedOpen(one, one), true),
+		Entry("open single value", decimal.Open(one, mustDecimal("1.0")), true),
+		Entry("reversed", decimal.Closed(two, one), true),
+		Entry("unbounded", decimal.Range{Lower: decimal.Bound{Unbounded: true}, Upper: decimal.Bound{Unbounded: true}}, false),
+		Entry("zero value", decimal.Range{}, false),
+	)
+
+	DescribeTable("Clamp", func(r decimal.Range, v, exp string) {
+		Ω(r.Clamp(mustDecimal(v))).Should(Equal(mustDecimal(exp)))
+	},
+		Entry("inside", decimal.Closed(one, two), "1.5", "1.5"),
+		Entry("below", decimal.Closed(one, two), "0.5", "1"),
+		Entry("above", decimal.Closed(one, two), "3", "2.00"),
+		Entry("open bound", decimal.Open(one, two), "2", "2.00"),
+		Entry("unbounded", decimal.AtLeast(one), "300", "300"),
+		Entry("empty", decimal.Closed(two, one), "300", "300"),
+	)
+
+	DescribeTable("Intersect", func(a, b decimal.Range, exp string, overlaps bool) {
+		Ω(a.Intersect(b).String()).Should(Equal(exp))
+		Ω(b.Intersect(a).String()).Should(Equal(exp))
+		Ω(a.Overlaps(b)).Should(Equal(overlaps))
+		Ω(b.Overlaps(a)).Should(Equal(overlaps))
+	},
+		Entry("overlap", decimal.Closed(one, two), decimal.Closed(mustDecimal("1.5"), mustDecimal("3")), "[1.5, 2.00]", true),
+		Entry("inside", decimal.AtLeast(one), decimal.Open(two, mustDecimal("3")), "(2.00, 3)", true),
+		Entry("touch closed", decimal.Closed(one, two), decimal.Closed(two, mustDecimal("3")), "[2.00, 2.00]", true),
+		Entry("touch half open", decimal.ClosedOpen(one, two), decimal.Closed(two, mustDecimal("3")), "[2.00, 2.00)", false),
+		Entry("same bound open wins", decimal.Closed(one, two), decimal.Open(one, two), "(1, 2.00)", true),
+		Entry("disjoint", decimal.AtMost(one), decimal.AtLeast(two), "[2.00, 1]", false),
+		Entry("unbounded", decimal.AtMost(two), decimal.GreaterThan(one), "(1, 2.00]", true),
+	)
+
+	It("String", func() {
+		Ω(decimal.LessThan(one).String()).Should(Equal("(-∞, 1)"))
+		Ω(decimal.AtLeast(two).String()).Should(Equal("[2.00, +∞)"))
+	})
+
+	Context("Tiers", func() {
+		It("Lookup", func() {
+			tiers, err := decimal.NewTiers(
+				decimal.LessThan(mustDecimal("1000")),
+				decimal.ClosedOpen(mustDecimal("1000"), mustDecimal("5000")),
+				decimal.Closed(mustDecimal("5000"), mustDecimal("10000")),
+				decimal.GreaterThan(mustDecimal("20000")),
+			)
+			Ω(err).Should(Succeed())
+			Ω(tiers.Len()).Should(Equal(4))
+			Ω(tiers.Range(1).String()).Should(Equal("[1000, 5000)"))
+
+			for v, exp := range map[string]int{
+				"-5": 0, "999.99": 0, "1000": 1, "4999.99": 1, "5000": 2, "10000": 2,
+				"10000.01": -1, "20000": -1, "20000.01": 3, "9223372036854775807": 3,
+			} {
+				i, ok := tiers.Lookup(mustDecimal(v))
+				Ω(i).Should(Equal(exp), v)
+				Ω(ok).Should(Equal(exp >= 0), v)
+			}
+		})
+
+		It("empty tiers", func() {
+			tiers, err := decimal.NewTiers()
+			Ω(err).Should(Succeed())
+			_, ok := tiers.Lookup(one)
+			Ω(ok).Should(BeFalse())
+		})
+
+		DescribeTable("NewTiers error", func(exp string, ranges ...decimal.Range) {
+			_, err := decimal.NewTiers(ranges...)
+			Ω(err).Should(MatchError(exp))
+		},
+			Entry("empty range", "[decimal] tier 1 [2.00, 1] is empty", decimal.AtMost(one), decimal.Closed(two, one)),
+			Entry("overlap", "[decimal] tier 1 [1, 2.00] overlaps or before tier 0 (-∞, 1]",
+				decimal.AtMost(one), decimal.Closed(one, two)),
+			Entry("not sorted", "[decimal] tier 1 (-∞, 1) overlaps or before tier 0 [2.00, +∞)",
+				decimal.AtLeast(two), decimal.LessThan(one)),
+		)
+	})
+})
@@ -0,0 +1,136 @@
+package decimal
+
+import "fmt"
+
+// RoundingMode specifies how to round a value to a scale.
+type RoundingMode int
+
+const (
+	// HalfUp round half away from zero, such as 2.5 to 3, -2.5 to -3. It is
+	// the rounding mode of Round() and arithmetic operations.
+	HalfUp RoundingMode = iota
+
+	// HalfDown round half toward zero, such as 2.5 to 2, -2.5 to -2.
+	HalfDown
+
+	// HalfEven round half to even, banker's rounding, such as 2.5 to 2, 3.5 to 4.
+	HalfEven
+
+	// Up round away from zero, such as 2.1 to 3, -2.1 to -3.
+	Up
+
+	// Down round toward zero, truncate, such as 2.9 to 2, -2.9 to -2.
+	Down
+
+	// Ceiling round toward positive infinity, such as 2.1 to 3, -2.9 to -2.
+	Ceiling
+
+	// Floor round toward negative infinity, such as 2.9 to 2, -2.1 to -3.
+	Floor
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case HalfUp:
+		return "HalfUp"
+	case HalfDown:
+		return "HalfDown"
+	case HalfEven:
+		return "HalfEven"
+	case Up:
+		return "Up"
+	case Down:
+		return "Down"
+	case Ceiling:
+		return "Ceiling"
+	case Floor:
+		return "Floor"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+}
+
+// RoundWithMode round decimal to specific scale using rounding mode. Panics
+// with ErrOverflow if digits of result out of int64 range.
+func (d Decimal) RoundWithMode(scale int, mode RoundingMode) Decimal {
+	if err := checkScale(scale); err != nil {
+		panic(err.Error())
+	}
+
+	diff := int(d.scale) - scale
+	if diff <= 0 {
+		return d.Round(scale)
+	}
+
+	p := powerOf10(diff)
+	q, r := d.digits/p, abs(d.digits%p)
+	if mode.away(d.digits < 0, q%2 != 0, r == 0, cmpInt64(r, p-r)) {
+		if d.digits < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Decimal{q, uint8(scale)}
+}
+
+// Truncate round decimal to specific scale toward zero, such as 1.29 to 1.2,
+// -1.29 to -1.2. Panics with ErrOverflow if digits of result out of int64
+// range.
+func (d Decimal) Truncate(scale int) Decimal {
+	return d.RoundWithMode(scale, Down)
+}
+
+// Floor round decimal to specific scale toward negative infinity, such as
+// 1.29 to 1.2, -1.21 to -1.3. Panics with ErrOverflow if digits of result out
+// of int64 range.
+func (d Decimal) Floor(scale int) Decimal {
+	return d.RoundWithMode(scale, Floor)
+}
+
+// Ceil round decimal to specific scale toward positive infinity, such as 1.21
+// to 1.3, -1.29 to -1.2. Panics with ErrOverflow if digits of result out of
+// int64 range.
+func (d Decimal) Ceil(scale int) Decimal {
+	return d.RoundWithMode(scale, Ceiling)
+}
+
+// away reports whether a quotient truncated toward zero should increase its
+// magnitude by one. neg is the sign of exact value, odd reports the truncated
+// quotient is odd, exact reports remainder is zero, half is the result of
+// comparing remainder with half of divisor.
+func (m RoundingMode) away(neg, odd, exact bool, half int) bool {
+	if exact {
+		return false
+	}
+
+	switch m {
+	case HalfUp:
+		return half >= 0
+	case HalfDown:
+		return half > 0
+	case HalfEven:
+		return half > 0 || (half == 0 && odd)
+	case Up:
+		return true
+	case Down:
+		return false
+	case Ceiling:
+		return !neg
+	case Floor:
+		return neg
+	default:
+		panic(fmt.Sprintf("[%s] unknown rounding mode %d", tag, int(m)))
+	}
+}
+
+func cmpInt64(a, b int64) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,58 @@
+package decimal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/testing/matcher"
+)
+
+var _ = Describe("RoundingMode", func() {
+	modes := []decimal.RoundingMode{decimal.HalfUp, decimal.HalfDown, decimal.HalfEven,
+		decimal.Up, decimal.Down, decimal.Ceiling, decimal.Floor}
+
+	// exp are results of modes in order: HalfUp, HalfDown, HalfEven, Up,
+	// Down, Ceiling, Floor
+	DescribeTable("RoundWithMode", func(s string, scale int, exp ...string) {
+		var d decimal.Decimal
+		Ω(decimal.FromString(s)).Should(matcher.Save(&d))
+		for i, mode := range modes {
+			Ω(d.RoundWithMode(scale, mode).String()).Should(Equal(exp[i]), mode.String())
+		}
+	},
+		Entry("5.5", "5.5", 0, "6", "5", "6", "6", "5", "6", "5"),
+		Entry("2.5", "2.5", 0, "3", "2", "2", "3", "2", "3", "2"),
+		Entry("1.6", "1.6", 0, "2", "2", "2", "2", "1", "2", "1"),
+		Entry("1.1", "1.1", 0, "1", "1", "1", "2", "1", "2", "1"),
+		Entry("1.0", "1.0", 0, "1", "1", "1", "1", "1", "1", "1"),
+		Entry("-1.0", "-1.0", 0, "-1", "-1", "-1", "-1", "-1", "-1", "-1"),
+		Entry("-1.1", "-1.1", 0, "-1", "-1", "-1", "-2", "-1", "-1", "-2"),
+		Entry("-1.6", "-1.6", 0, "-2", "-2", "-2", "-2", "-1", "-1", "-2"),
+		Entry("-2.5", "-2.5", 0, "-3", "-2", "-2", "-3", "-2", "-2", "-3"),
+		Entry("-5.5", "-5.5", 0, "-6", "-5", "-6", "-6", "-5", "-5", "-6"),
+		Entry("more digits", "0.125001", 2, "0.13", "0.13", "0.13", "0.13", "0.12", "0.13", "0.12"),
+		Entry("half at scale", "0.125", 2, "0.13", "0.12", "0.12", "0.13", "0.12", "0.13", "0.12"),
+		Entry("expand scale", "0.1", 2, "0.10", "0.10", "0.10", "0.10", "0.10", "0.10", "0.10"),
+		Entry("max scale", "-0.000000000000000001", 0, "0", "0", "0", "-1", "0", "0", "-1"),
+	)
+
+	DescribeTable("Truncate, Floor and Ceil", func(s string, scale int, truncate, floor, ceil string) {
+		var d decimal.Decimal
+		Ω(decimal.FromString(s)).Should(matcher.Save(&d))
+		Ω(d.Truncate(scale).String()).Should(Equal(truncate))
+		Ω(d.Floor(scale).String()).Should(Equal(floor))
+		Ω(d.Ceil(scale).String()).Should(Equal(ceil))
+	},
+		Entry("positive", "1.29", 1, "1.2", "1.2", "1.3"),
+		Entry("negative", "-1.21", 1, "-1.2", "-1.3", "-1.2"),
+		Entry("exact", "-1.20", 1, "-1.2", "-1.2", "-1.2"),
+		Entry("to integer", "2.5", 0, "2", "2", "3"),
+		Entry("expand scale", "2.5", 2, "2.50", "2.50", "2.50"),
+	)
+
+	It("String", func() {
+		Ω(decimal.HalfEven.String()).Should(Equal("HalfEven"))
+		Ω(decimal.RoundingMode(100).String()).Should(Equal("RoundingMode(100)"))
+	})
+})
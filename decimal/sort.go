@@ -0,0 +1,28 @@
+package decimal
+
+import "sort"
+
+// Compare returns -1 if a < b, 1 if a > b, 0 if equal, same as a.Cmp(b).
+// Usable as comparison function of slices.SortFunc().
+func Compare(a, b Decimal) int {
+	return a.Cmp(b)
+}
+
+// Slice attaches the methods of sort.Interface to []Decimal, sorting in
+// increasing order.
+type Slice []Decimal
+
+func (s Slice) Len() int           { return len(s) }
+func (s Slice) Less(i, j int) bool { return s[i].Cmp(s[j]) < 0 }
+func (s Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Sort sorts values in increasing order, numerically equal values in
+// different scales keep their original order.
+func Sort(values []Decimal) {
+	sort.Stable(Slice(values))
+}
+
+// IsSorted reports whether values are sorted in increasing order.
+func IsSorted(values []Decimal) bool {
+	return sort.IsSorted(Slice(values))
+}
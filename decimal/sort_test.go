@@ -0,0 +1,43 @@
+package decimal_test
+
+import (
+	"sort"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+)
+
+var _ = Describe("Sort", func() {
+	toStrings := func(values []decimal.Decimal) string {
+		r := make([]string, len(values))
+		for i, v := range values {
+			r[i] = v.String()
+		}
+		return strings.Join(r, " ")
+	}
+
+	It("Sort", func() {
+		values := toDecimals("3 -9223372036854775808 1.50 0.000000000000000001 1.5 9223372036854775807 -1")
+		Ω(decimal.IsSorted(values)).Should(BeFalse())
+		decimal.Sort(values)
+		Ω(toStrings(values)).Should(Equal("-9223372036854775808 -1 0.000000000000000001 1.50 1.5 3 9223372036854775807"))
+		Ω(decimal.IsSorted(values)).Should(BeTrue())
+	})
+
+	It("Slice", func() {
+		values := toDecimals("2 1 3")
+		sort.Sort(sort.Reverse(decimal.Slice(values)))
+		Ω(toStrings(values)).Should(Equal("3 2 1"))
+	})
+
+	It("Compare", func() {
+		values := toDecimals("2 -1 1.5")
+		sort.Slice(values, func(i, j int) bool {
+			return decimal.Compare(values[i], values[j]) < 0
+		})
+		Ω(toStrings(values)).Should(Equal("-1 1.5 2"))
+		Ω(decimal.Compare(values[0], values[0])).Should(Equal(0))
+	})
+})
@@ -0,0 +1,228 @@
+package decimal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChineseUnits are unit names of Chinese uppercase amount.
+type ChineseUnits struct {
+	Major    string // unit of integer part, such as "元" or "圆"
+	Jiao     string // unit of 0.1, such as "角"
+	Fen      string // unit of 0.01, such as "分"
+	Whole    string // suffix if no Fen, such as "整"
+	Negative string // prefix of negative amount, such as "负"
+}
+
+// RMBUnits are unit names of 人民币 uppercase amount.
+var RMBUnits = ChineseUnits{Major: "元", Jiao: "角", Fen: "分", Whole: "整", Negative: "负"}
+
+var (
+	chineseDigits = [...]string{"零", "壹", "贰", "叁", "肆", "伍", "陆", "柒", "捌", "玖"}
+	chinesePlaces = [...]string{"", "拾", "佰", "仟"}
+)
+
+// ChineseUpper returns amount in Chinese financial uppercase numerals (大写
+// 金额), round half away from zero to 0.01, such as 1234.56 to
+// "壹仟贰佰叁拾肆元伍角陆分", 1000 to "壹仟元整", 1000.05 to "壹仟元零伍分",
+// 0.5 to "伍角整".
+func (d Decimal) ChineseUpper(units ChineseUnits) string {
+	n, cents := d.amountParts()
+
+	var b strings.Builder
+	if d.digits < 0 && (n != 0 || cents != 0) {
+		b.WriteString(units.Negative)
+	}
+
+	jiao, fen := cents/10, cents%10
+	if n != 0 || cents == 0 {
+		writeChineseInt(&b, n)
+		b.WriteString(units.Major)
+	}
+	if jiao != 0 {
+		b.WriteString(chineseDigits[jiao] + units.Jiao)
+	} else if n != 0 && fen != 0 {
+		b.WriteString(chineseDigits[0])
+	}
+	if fen != 0 {
+		b.WriteString(chineseDigits[fen] + units.Fen)
+	} else {
+		b.WriteString(units.Whole)
+	}
+	return b.String()
+}
+
+// writeChineseInt writes n in uppercase numerals, 零 inserted for zeros
+// between non-zero digits.
+func writeChineseInt(b *strings.Builder, n uint64) {
+	var (
+		unit uint64
+		name string
+	)
+	switch {
+	case n >= 1e8:
+		unit, name = 1e8, "亿"
+	case n >= 1e4:
+		unit, name = 1e4, "万"
+	default:
+		writeChineseGroup(b, n)
+		return
+	}
+
+	high, low := n/unit, n%unit
+	writeChineseInt(b, high)
+	b.WriteString(name)
+	if low == 0 {
+		return
+	}
+	if low < unit/10 {
+		b.WriteString(chineseDigits[0])
+	}
+	writeChineseInt(b, low)
+}
+
+// writeChineseGroup writes n less than 10000.
+func writeChineseGroup(b *strings.Builder, n uint64) {
+	if n == 0 {
+		b.WriteString(chineseDigits[0])
+		return
+	}
+
+	zero := false
+	for place := 3; place >= 0; place-- {
+		digit := n / uint64(powerOf10(place)) % 10
+		switch {
+		case digit == 0:
+			zero = zero || n >= uint64(powerOf10(place))
+		default:
+			if zero {
+				b.WriteString(chineseDigits[0])
+				zero = false
+			}
+			b.WriteString(chineseDigits[digit] + chinesePlaces[place])
+		}
+	}
+}
+
+// EnglishUnits are unit names of amount in English words. If Minor is empty,
+// cents written as fraction like on checks, such as "Twelve and 50/100".
+type EnglishUnits struct {
+	Major, MajorSingular string // unit of integer part, such as "dollars", "dollar"
+	Minor, MinorSingular string // unit of 0.01, such as "cents", "cent"
+	Negative             string // prefix of negative amount, "Minus" if empty
+}
+
+var (
+	// USDUnits are unit names of US dollar.
+	USDUnits = EnglishUnits{Major: "dollars", MajorSingular: "dollar", Minor: "cents", MinorSingular: "cent"}
+
+	// CheckUnits writes amount like on checks, such as
+	// "One thousand two hundred thirty-four and 56/100".
+	CheckUnits = EnglishUnits{}
+)
+
+var (
+	englishOnes = [...]string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	englishTens   = [...]string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	englishGroups = [...]string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// EnglishWords returns amount in English words, round half away from zero to
+// 0.01, first letter capitalized. Such as 1234.56 in CheckUnits is
+// "One thousand two hundred thirty-four and 56/100", in USDUnits is
+// "One thousand two hundred thirty-four dollars and fifty-six cents".
+func (d Decimal) EnglishWords(units EnglishUnits) string {
+	n, cents := d.amountParts()
+
+	var words []string
+	if d.digits < 0 && (n != 0 || cents != 0) {
+		if units.Negative == "" {
+			words = append(words, "minus")
+		} else {
+			words = append(words, units.Negative)
+		}
+	}
+
+	if units.Minor == "" {
+		words = appendEnglishInt(words, n)
+		words = append(words, "and", fmt.Sprintf("%02d/100", cents))
+		words = appendUnit(words, n == 1, units.Major, units.MajorSingular)
+	} else {
+		if n != 0 || cents == 0 {
+			words = appendEnglishInt(words, n)
+			words = appendUnit(words, n == 1, units.Major, units.MajorSingular)
+		}
+		if cents != 0 {
+			if n != 0 {
+				words = append(words, "and")
+			}
+			words = appendEnglishInt(words, cents)
+			words = appendUnit(words, cents == 1, units.Minor, units.MinorSingular)
+		}
+	}
+
+	s := strings.Join(words, " ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func appendUnit(words []string, one bool, plural, singular string) []string {
+	switch {
+	case one && singular != "":
+		return append(words, singular)
+	case plural != "":
+		return append(words, plural)
+	default:
+		return words
+	}
+}
+
+// appendEnglishInt appends words of n.
+func appendEnglishInt(words []string, n uint64) []string {
+	if n == 0 {
+		return append(words, englishOnes[0])
+	}
+
+	var groups [len(englishGroups)]uint64
+	for i := range groups {
+		groups[i], n = n%1000, n/1000
+	}
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			continue
+		}
+		if g >= 100 {
+			words = append(words, englishOnes[g/100], "hundred")
+			g %= 100
+		}
+		switch {
+		case g >= 20 && g%10 != 0:
+			words = append(words, englishTens[g/10]+"-"+englishOnes[g%10])
+		case g >= 20:
+			words = append(words, englishTens[g/10])
+		case g > 0:
+			words = append(words, englishOnes[g])
+		}
+		if i > 0 {
+			words = append(words, englishGroups[i])
+		}
+	}
+	return words
+}
+
+// amountParts returns absolute integer part and cents of d rounded half away
+// from zero to 0.01.
+func (d Decimal) amountParts() (n, cents uint64) {
+	if d.scale <= 2 {
+		v := absUint64(d.digits)
+		p := uint64(powerOf10(int(d.scale)))
+		return v / p, v % p * uint64(powerOf10(2-int(d.scale)))
+	}
+
+	// |digits| < 2^63, rounded fits in uint64.
+	v := absUint64(d.digits)
+	p := uint64(powerOf10(int(d.scale) - 2))
+	v = (v + p/2) / p
+	return v / 100, v % 100
+}
@@ -0,0 +1,92 @@
+package decimal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+)
+
+var _ = Describe("Words", func() {
+	DescribeTable("ChineseUpper", func(s, exp string) {
+		Ω(toDecimal(s).ChineseUpper(decimal.RMBUnits)).Should(Equal(exp))
+	},
+		Entry("example", "1234.56", "壹仟贰佰叁拾肆元伍角陆分"),
+		Entry("zero", "0", "零元整"),
+		Entry("zero with scale", "0.00", "零元整"),
+		Entry("one", "1", "壹元整"),
+		Entry("ten", "10", "壹拾元整"),
+		Entry("fifteen", "15", "壹拾伍元整"),
+		Entry("integer", "1000", "壹仟元整"),
+		Entry("zero in middle", "1001", "壹仟零壹元整"),
+		Entry("zeros in middle", "1010", "壹仟零壹拾元整"),
+		Entry("trailing zeros in group", "1100", "壹仟壹佰元整"),
+		Entry("only jiao", "0.5", "伍角整"),
+		Entry("only fen", "0.05", "伍分"),
+		Entry("jiao and fen", "0.56", "伍角陆分"),
+		Entry("zero jiao", "1000.05", "壹仟元零伍分"),
+		Entry("zero jiao small", "1.05", "壹元零伍分"),
+		Entry("jiao no fen", "1000.50", "壹仟元伍角整"),
+		Entry("wan", "10000", "壹万元整"),
+		Entry("wan with ten", "100100", "壹拾万零壹佰元整"),
+		Entry("wan zero group", "10000001", "壹仟万零壹元整"),
+		Entry("wan full group", "10001000", "壹仟万壹仟元整"),
+		Entry("wan and zero", "10010000", "壹仟零壹万元整"),
+		Entry("yi", "100000000", "壹亿元整"),
+		Entry("yi zero wan", "100000001", "壹亿零壹元整"),
+		Entry("yi and wan", "100010000", "壹亿零壹万元整"),
+		Entry("yi and thousand wan", "110000000", "壹亿壹仟万元整"),
+		Entry("yi with fen", "200000000.01", "贰亿元零壹分"),
+		Entry("wan yi", "1000100000000", "壹万零壹亿元整"),
+		Entry("yi skip wan group", "1000000100", "壹拾亿零壹佰元整"),
+		Entry("many digits", "109080706050.40", "壹仟零玖拾亿捌仟零柒拾万陆仟零伍拾元肆角整"),
+		Entry("negative", "-1234.56", "负壹仟贰佰叁拾肆元伍角陆分"),
+		Entry("round half up", "0.125", "壹角叁分"),
+		Entry("round to whole", "9.995", "壹拾元整"),
+		Entry("negative round to zero", "-0.001", "零元整"),
+		Entry("max", "9223372036854775807", "玖佰贰拾贰亿叁仟叁佰柒拾贰万零叁佰陆拾捌亿伍仟肆佰柒拾柒万伍仟捌佰零柒元整"),
+		Entry("min", "-92233720368547758.08", "负玖亿贰仟贰佰叁拾叁万柒仟贰佰零叁亿陆仟捌佰伍拾肆万柒仟柒佰伍拾捌元零捌分"),
+	)
+
+	It("ChineseUpper units", func() {
+		units := decimal.RMBUnits
+		units.Major = "圆"
+		Ω(toDecimal("1.05").ChineseUpper(units)).Should(Equal("壹圆零伍分"))
+	})
+
+	DescribeTable("EnglishWords check", func(s, exp string) {
+		Ω(toDecimal(s).EnglishWords(decimal.CheckUnits)).Should(Equal(exp))
+	},
+		Entry("example", "1234.56", "One thousand two hundred thirty-four and 56/100"),
+		Entry("zero", "0", "Zero and 00/100"),
+		Entry("cents only", "0.05", "Zero and 05/100"),
+		Entry("teens", "13.1", "Thirteen and 10/100"),
+		Entry("tens", "40", "Forty and 00/100"),
+		Entry("hundred", "100", "One hundred and 00/100"),
+		Entry("hundred and one", "101", "One hundred one and 00/100"),
+		Entry("thousand", "1000", "One thousand and 00/100"),
+		Entry("skip group", "1000001", "One million one and 00/100"),
+		Entry("round", "0.995", "One and 00/100"),
+		Entry("negative", "-21.5", "Minus twenty-one and 50/100"),
+		Entry("max", "9223372036854775807", "Nine quintillion two hundred twenty-three quadrillion three hundred "+
+			"seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five "+
+			"thousand eight hundred seven and 00/100"),
+	)
+
+	DescribeTable("EnglishWords dollars", func(s, exp string) {
+		Ω(toDecimal(s).EnglishWords(decimal.USDUnits)).Should(Equal(exp))
+	},
+		Entry("example", "1234.56", "One thousand two hundred thirty-four dollars and fifty-six cents"),
+		Entry("zero", "0.00", "Zero dollars"),
+		Entry("one", "1", "One dollar"),
+		Entry("one cent", "0.01", "One cent"),
+		Entry("one and one", "1.01", "One dollar and one cent"),
+		Entry("no cents", "20", "Twenty dollars"),
+		Entry("negative", "-0.3", "Minus thirty cents"),
+	)
+
+	It("EnglishWords units", func() {
+		units := decimal.EnglishUnits{Major: "dollars", Negative: "negative"}
+		Ω(toDecimal("-12.5").EnglishWords(units)).Should(Equal("Negative twelve and 50/100 dollars"))
+	})
+})
@@ -0,0 +1,67 @@
+package finance
+
+import (
+	"math/big"
+
+	"github.com/redforks/math/decimal"
+)
+
+// prec is the precision of big.Float used in root finding of IRR and XIRR,
+// far more than digits of decimal.Decimal.
+const prec = 256
+
+// fromRat round r to scale half away from zero, returns decimal.ErrOverflow
+// if out of range.
+func fromRat(r *big.Rat, scale int) (decimal.Decimal, error) {
+	return decimal.FromBigRat(r, scale, decimal.HalfUp)
+}
+
+// quoRound returns num / den round to scale, decimal.ErrDivisionByZero if den
+// is zero.
+func quoRound(num, den *big.Rat, scale int) (decimal.Decimal, error) {
+	if den.Sign() == 0 {
+		return decimal.Decimal{}, decimal.ErrDivisionByZero
+	}
+	return fromRat(num.Quo(num, den), scale)
+}
+
+// mulRound returns a * b round to scale.
+func mulRound(a, b decimal.Decimal, scale int) (decimal.Decimal, error) {
+	return fromRat(new(big.Rat).Mul(a.ToBigRat(), b.ToBigRat()), scale)
+}
+
+// powRat returns x^n exactly, n must not be negative.
+func powRat(x *big.Rat, n int) *big.Rat {
+	r := newRat(1)
+	p := new(big.Rat).Set(x)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r.Mul(r, p)
+		}
+		if n > 1 {
+			p.Mul(p, p)
+		}
+	}
+	return r
+}
+
+func newRat(v int64) *big.Rat {
+	return new(big.Rat).SetInt64(v)
+}
+
+// powInt returns x^n, n must not be negative.
+func powInt(x *big.Float, n int) *big.Float {
+	r := new(big.Float).SetPrec(prec).SetInt64(1)
+	p := new(big.Float).SetPrec(prec).Set(x)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r.Mul(r, p)
+		}
+		p.Mul(p, p)
+	}
+	return r
+}
+
+func newFloat(v int64) *big.Float {
+	return new(big.Float).SetPrec(prec).SetInt64(v)
+}
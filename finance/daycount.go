@@ -0,0 +1,161 @@
+package finance
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/redforks/math/decimal"
+)
+
+// DayCount is a day count convention, determines how interest accrues over
+// time.
+type DayCount int
+
+const (
+	// Actual360 counts actual days, 360 days a year.
+	Actual360 DayCount = iota
+
+	// Actual365Fixed counts actual days, 365 days a year, even in leap years.
+	Actual365Fixed
+
+	// ActualActualISDA counts actual days, days in leap years divided by 366,
+	// days in other years divided by 365.
+	ActualActualISDA
+
+	// Thirty360US counts 30 days a month, 360 days a year, with US (NASD)
+	// end of month rules: last day of February treated as day 30 of the
+	// month; day 31 of the end date treated as day 30 if start date is day 30
+	// or 31; day 31 of the start date treated as day 30.
+	Thirty360US
+
+	// ThirtyE360 counts 30 days a month, 360 days a year, day 31 of start or
+	// end date treated as day 30, also known as Eurobond basis.
+	ThirtyE360
+)
+
+func (c DayCount) String() string {
+	switch c {
+	case Actual360:
+		return "Actual/360"
+	case Actual365Fixed:
+		return "Actual/365F"
+	case ActualActualISDA:
+		return "Actual/Actual ISDA"
+	case Thirty360US:
+		return "30/360 US"
+	case ThirtyE360:
+		return "30E/360"
+	default:
+		return fmt.Sprintf("DayCount(%d)", int(c))
+	}
+}
+
+// Days returns number of days between start and end date in the convention,
+// time of day ignored.
+func (c DayCount) Days(start, end time.Time) int {
+	switch c {
+	case Thirty360US, ThirtyE360:
+		y1, m1, d1 := start.Date()
+		y2, m2, d2 := end.Date()
+		if c == Thirty360US {
+			if isLastDayOfFeb(start) {
+				if isLastDayOfFeb(end) {
+					d2 = 30
+				}
+				d1 = 30
+			}
+			if d2 == 31 && d1 >= 30 {
+				d2 = 30
+			}
+		} else if d2 == 31 {
+			d2 = 30
+		}
+		if d1 == 31 {
+			d1 = 30
+		}
+		return 360*(y2-y1) + 30*(int(m2)-int(m1)) + d2 - d1
+	default:
+		return actualDays(start, end)
+	}
+}
+
+// yearFraction returns the fraction of year between start and end date.
+func (c DayCount) yearFraction(start, end time.Time) *big.Rat {
+	switch c {
+	case Actual365Fixed:
+		return big.NewRat(int64(c.Days(start, end)), 365)
+	case ActualActualISDA:
+		r := new(big.Rat)
+		for y := start.Year(); y <= end.Year(); y++ {
+			from, to := date(y, 1, 1), date(y+1, 1, 1)
+			if y == start.Year() {
+				from = start
+			}
+			if y == end.Year() {
+				to = end
+			}
+			r.Add(r, big.NewRat(int64(actualDays(from, to)), int64(daysInYear(y))))
+		}
+		return r
+	default:
+		return big.NewRat(int64(c.Days(start, end)), 360)
+	}
+}
+
+// AccrueAnnual returns interest of principal accrued from start to end date at
+// annual rate, principal * rate * year fraction of the day count convention,
+// round to scale using rounding mode.
+func AccrueAnnual(principal, annualRate decimal.Decimal, start, end time.Time, c DayCount,
+	scale int, mode decimal.RoundingMode) (decimal.Decimal, error) {
+	if err := checkPeriod(start, end, scale); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	r := new(big.Rat).Mul(principal.ToBigRat(), annualRate.ToBigRat())
+	return decimal.FromBigRat(r.Mul(r, c.yearFraction(start, end)), scale, mode)
+}
+
+// AccrueDaily returns interest of principal accrued from start to end date at
+// daily rate, principal * rate * days counted by the day count convention,
+// round to scale using rounding mode.
+func AccrueDaily(principal, dailyRate decimal.Decimal, start, end time.Time, c DayCount,
+	scale int, mode decimal.RoundingMode) (decimal.Decimal, error) {
+	if err := checkPeriod(start, end, scale); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	r := new(big.Rat).Mul(principal.ToBigRat(), dailyRate.ToBigRat())
+	return decimal.FromBigRat(r.Mul(r, big.NewRat(int64(c.Days(start, end)), 1)), scale, mode)
+}
+
+func checkPeriod(start, end time.Time, scale int) error {
+	if actualDays(start, end) < 0 {
+		return fmt.Errorf("[%s] end date %s before start date %s", tag,
+			end.Format("2006-01-02"), start.Format("2006-01-02"))
+	}
+	return checkScale(scale)
+}
+
+// actualDays returns number of calendar days from start to end, time of day
+// and time zone ignored.
+func actualDays(start, end time.Time) int {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+	return int(date(y2, m2, d2).Sub(date(y1, m1, d1)).Hours() / 24)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func daysInYear(year int) int {
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		return 366
+	}
+	return 365
+}
+
+func isLastDayOfFeb(t time.Time) bool {
+	return t.Month() == time.February && t.AddDate(0, 0, 1).Month() == time.March
+}
@@ -0,0 +1,83 @@
+package finance_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	. "github.com/redforks/math/finance"
+)
+
+var _ = Describe("Day count", func() {
+	toDate := func(s string) time.Time {
+		t, err := time.Parse("2006-01-02", s)
+		Ω(err).Should(Succeed())
+		return t
+	}
+
+	DescribeTable("Days", func(c DayCount, start, end string, exp int) {
+		Ω(c.Days(toDate(start), toDate(end))).Should(Equal(exp))
+	},
+		Entry("actual", Actual360, "2023-01-01", "2023-07-01", 181),
+		Entry("actual leap year", Actual365Fixed, "2024-01-01", "2025-01-01", 366),
+		Entry("actual reversed", Actual360, "2023-01-02", "2023-01-01", -1),
+		Entry("30/360 US end of January", Thirty360US, "2007-01-31", "2007-02-28", 28),
+		Entry("30/360 US end of February", Thirty360US, "2007-02-28", "2007-03-31", 30),
+		Entry("30E/360 end of February", ThirtyE360, "2007-02-28", "2007-03-31", 32),
+		Entry("30/360 US leap February", Thirty360US, "2008-02-29", "2009-02-28", 360),
+		Entry("30E/360 leap February", ThirtyE360, "2008-02-29", "2009-02-28", 359),
+		Entry("30/360 US day 31 to 31", Thirty360US, "2007-01-31", "2007-03-31", 60),
+		Entry("30/360 US day 15 to 31", Thirty360US, "2007-01-15", "2007-03-31", 76),
+		Entry("30E/360 day 15 to 31", ThirtyE360, "2007-01-15", "2007-03-31", 75),
+	)
+
+	DescribeTable("AccrueAnnual", func(c DayCount, start, end string, mode decimal.RoundingMode, exp string) {
+		Ω(AccrueAnnual(toDecimal("10000"), toDecimal("0.05"), toDate(start), toDate(end), c, 2, mode)).
+			Should(Equal(toDecimal(exp)))
+	},
+		Entry("Actual/360", Actual360, "2023-01-01", "2023-07-01", decimal.HalfUp, "251.39"),
+		Entry("Actual/365F", Actual365Fixed, "2023-01-01", "2023-07-01", decimal.HalfUp, "247.95"),
+		Entry("Actual/365F round down", Actual365Fixed, "2023-01-01", "2023-07-01", decimal.Down, "247.94"),
+		Entry("Actual/365F leap year", Actual365Fixed, "2024-01-01", "2025-01-01", decimal.HalfUp, "501.37"),
+		Entry("Actual/Actual ISDA leap year", ActualActualISDA, "2024-01-01", "2025-01-01", decimal.HalfUp, "500.00"),
+		Entry("Actual/Actual ISDA cross year", ActualActualISDA, "2007-12-28", "2008-02-28", decimal.HalfUp, "84.71"),
+		Entry("30/360 US", Thirty360US, "2023-01-31", "2023-07-31", decimal.HalfUp, "250.00"),
+		Entry("30E/360", ThirtyE360, "2023-01-15", "2023-07-31", decimal.HalfUp, "270.83"),
+		Entry("same day", Actual360, "2023-01-01", "2023-01-01", decimal.HalfUp, "0.00"),
+	)
+
+	It("AccrueAnnual half even", func() {
+		// 1000 * 0.0365 * 5 / 365 = 0.5
+		start := toDate("2023-01-01")
+		end := toDate("2023-01-06")
+		Ω(AccrueAnnual(toDecimal("1000"), toDecimal("0.0365"), start, end, Actual365Fixed, 0, decimal.HalfEven)).
+			Should(Equal(toDecimal("0")))
+		Ω(AccrueAnnual(toDecimal("1000"), toDecimal("0.0365"), start, end, Actual365Fixed, 0, decimal.HalfUp)).
+			Should(Equal(toDecimal("1")))
+		Ω(AccrueAnnual(toDecimal("-1000"), toDecimal("0.0365"), start, end, Actual365Fixed, 0, decimal.Floor)).
+			Should(Equal(toDecimal("-1")))
+		Ω(AccrueAnnual(toDecimal("-1000"), toDecimal("0.0365"), start, end, Actual365Fixed, 0, decimal.Ceiling)).
+			Should(Equal(toDecimal("0")))
+	})
+
+	DescribeTable("AccrueDaily", func(c DayCount, start, end string, exp string) {
+		Ω(AccrueDaily(toDecimal("10000"), toDecimal("0.0005"), toDate(start), toDate(end), c, 2, decimal.HalfUp)).
+			Should(Equal(toDecimal(exp)))
+	},
+		Entry("actual", Actual360, "2023-01-01", "2023-01-31", "150.00"),
+		Entry("30/360", Thirty360US, "2023-01-31", "2023-02-28", "140.00"),
+	)
+
+	It("end before start", func() {
+		_, err := AccrueAnnual(toDecimal("1"), toDecimal("0.01"), toDate("2023-01-02"), toDate("2023-01-01"),
+			Actual360, 2, decimal.HalfUp)
+		Ω(err).Should(MatchError("[math-finance] end date 2023-01-01 before start date 2023-01-02"))
+	})
+
+	It("String", func() {
+		Ω(ActualActualISDA.String()).Should(Equal("Actual/Actual ISDA"))
+		Ω(DayCount(10).String()).Should(Equal("DayCount(10)"))
+	})
+})
@@ -0,0 +1,277 @@
+package finance
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/redforks/math/decimal"
+)
+
+// ErrNoSolution indicates that IRR or XIRR can not find a rate makes net
+// present value zero, such as all cash flows are positive.
+var ErrNoSolution = fmt.Errorf("[%s] no solution found", tag)
+
+// daysPerYear is the XIRR year.
+const daysPerYear = 365
+
+// IRR returns internal rate of return per period of cash flows, the rate makes
+// NPV(rate, cashFlows) zero. cashFlows[i] occurs at the end of period i.
+//
+// IRR solved in float64 by Newton's method, fallback to bisection if Newton's
+// method not converge, then refined in big.Float and round to scale. The
+// rounded rate is verified by sign change of NPV in its rounding range.
+func IRR(cashFlows []decimal.Decimal, scale int) (decimal.Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	periods := make([]int, len(cashFlows))
+	for i := range periods {
+		periods[i] = i
+	}
+	return solveRate(cashFlows, periods, 1, scale)
+}
+
+// XIRR returns annual internal rate of return of cash flows occurs at specific
+// dates, the rate makes sum(cashFlows[i] / (1+rate)^((dates[i]-dates[0])/365))
+// zero, dates counted in whole calendar days, time of day and time zone
+// ignored, as spreadsheet XIRR. Solved in the same way as IRR.
+func XIRR(cashFlows []decimal.Decimal, dates []time.Time, scale int) (decimal.Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return decimal.Decimal{}, err
+	}
+	if len(cashFlows) != len(dates) {
+		return decimal.Decimal{}, fmt.Errorf("[%s] %d cash flows but %d dates", tag, len(cashFlows), len(dates))
+	}
+
+	periods := make([]int, len(dates))
+	for i, d := range dates {
+		periods[i] = actualDays(dates[0], d)
+	}
+	return solveRate(cashFlows, periods, daysPerYear, scale)
+}
+
+// solveRate finds rate makes sum(cashFlows[i] / (1+rate)^(periods[i]/k))
+// zero, round to scale.
+func solveRate(cashFlows []decimal.Decimal, periods []int, k, scale int) (decimal.Decimal, error) {
+	// reduce periods and k by their gcd, k is 1 if all periods are whole
+	// years, so that npv is exact rational in roundRate.
+	g := k
+	for _, n := range periods {
+		g = gcd(g, n)
+	}
+	times := make([]float64, len(periods))
+	for i := range periods {
+		periods[i] /= g
+		times[i] = float64(periods[i]) / float64(k/g)
+	}
+	k /= g
+
+	values := toFloats(cashFlows)
+	pos, neg := false, false
+	for _, v := range values {
+		pos = pos || v > 0
+		neg = neg || v < 0
+	}
+	if !pos || !neg {
+		return decimal.Decimal{}, ErrNoSolution
+	}
+
+	npv := func(rate float64) (v, dv float64) {
+		for i, cf := range values {
+			f := math.Pow(1+rate, -times[i])
+			v += cf * f
+			dv -= times[i] * cf * f / (1 + rate)
+		}
+		return
+	}
+
+	const tolerance = 1e-12
+	rate := 0.1
+	for i := 0; i < 100; i++ {
+		v, dv := npv(rate)
+		if dv == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			break
+		}
+
+		next := rate - v/dv
+		if next <= -1 || math.IsNaN(next) {
+			break
+		}
+		if math.Abs(next-rate) < tolerance {
+			return roundRate(cashFlows, periods, k, next, scale)
+		}
+		rate = next
+	}
+
+	// Newton's method not converge, find a range npv changes sign, then
+	// bisection. Rate less than -99% is meaningless and easily overflow float64.
+	lo, hi := -0.99, 1.0
+	vLo, _ := npv(lo)
+	vHi, _ := npv(hi)
+	for ; math.Signbit(vLo) == math.Signbit(vHi); vHi, _ = npv(hi) {
+		if hi > 1e6 {
+			return decimal.Decimal{}, ErrNoSolution
+		}
+		hi *= 2
+	}
+
+	for hi-lo > tolerance {
+		mid := (lo + hi) / 2
+		v, _ := npv(mid)
+		if math.Signbit(v) == math.Signbit(vLo) {
+			lo, vLo = mid, v
+		} else {
+			hi = mid
+		}
+	}
+	return roundRate(cashFlows, periods, k, (lo+hi)/2, scale)
+}
+
+// roundRate refines rate, a root of npv found in float64, and round it to
+// scale half away from zero. npv expressed as polynomial of
+// x = (1+rate)^(-1/k): sum(cashFlows[i] * x^periods[i]), its root refined by
+// Newton's method in big.Float. Returns ErrNoSolution if npv not changes sign
+// in the rounding range of the result or its neighbors.
+func roundRate(cashFlows []decimal.Decimal, periods []int, k int, rate float64, scale int) (decimal.Decimal, error) {
+	cfs := make([]*big.Float, len(cashFlows))
+	for i, cf := range cashFlows {
+		cfs[i] = cf.ToBigFloat(prec)
+	}
+	npv := func(x *big.Float) (v, dv *big.Float) {
+		v, dv = newFloat(0), newFloat(0)
+		for i, cf := range cfs {
+			t := new(big.Float).SetPrec(prec).Mul(cf, powSigned(x, periods[i]))
+			v.Add(v, t)
+			dv.Add(dv, t.Mul(t, newFloat(int64(periods[i]))).Quo(t, x))
+		}
+		return v, dv
+	}
+
+	x, ok := newton(newFloat(0).SetFloat64(math.Pow(1+rate, -1/float64(k))), npv)
+	if !ok {
+		return decimal.Decimal{}, ErrNoSolution
+	}
+	r := powSigned(x, -k)
+	r.Sub(r, newFloat(1))
+	c, err := decimal.FromBigFloat(r, scale, decimal.HalfUp)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	// sign returns sign of npv at rate, exact if k is 1.
+	sign := func(rate *big.Rat) int {
+		a := new(big.Rat).Add(rate, big.NewRat(1, 1))
+		if k == 1 {
+			x := a.Inv(a)
+			v, t := new(big.Rat), new(big.Rat)
+			for i, cf := range cashFlows {
+				v.Add(v, t.Mul(cf.ToBigRat(), ratPow(x, periods[i])))
+			}
+			return v.Sign()
+		}
+
+		// x = a^(-1/k), root of a*x^k - 1
+		af := new(big.Float).SetPrec(prec).SetRat(a)
+		f, _ := af.Float64()
+		x, _ := newton(newFloat(0).SetFloat64(math.Pow(f, -1/float64(k))), func(x *big.Float) (v, dv *big.Float) {
+			v = powInt(x, k)
+			v.Mul(v, af)
+			dv = new(big.Float).SetPrec(prec).Mul(v, newFloat(int64(k)))
+			return v.Sub(v, newFloat(1)), dv.Quo(dv, x)
+		})
+		v, _ := npv(x)
+		return v.Sign()
+	}
+
+	// r maybe out of the rounding range of c by computation error if close to
+	// its boundary, try neighbors of c.
+	ulp := decimal.New(1, scale).ToBigRat()
+	half := new(big.Rat).Mul(ulp, big.NewRat(1, 2))
+	for _, d := range []int64{0, 1, -1} {
+		mid := new(big.Rat).Add(c.ToBigRat(), new(big.Rat).Mul(ulp, big.NewRat(d, 1)))
+		lo, hi := new(big.Rat).Sub(mid, half), new(big.Rat).Add(mid, half)
+		if minusOne := big.NewRat(-1, 1); hi.Cmp(minusOne) <= 0 {
+			continue
+		} else if lo.Cmp(minusOne) <= 0 {
+			// rate greater than -1, narrow lo to the middle of -1 and hi.
+			lo.Add(hi, minusOne).Quo(lo, big.NewRat(2, 1))
+		}
+
+		sLo, sHi := sign(lo), sign(hi)
+		switch {
+		case sLo == 0:
+			return decimal.FromBigRat(lo, scale, decimal.HalfUp)
+		case sHi == 0:
+			return decimal.FromBigRat(hi, scale, decimal.HalfUp)
+		case sLo != sHi:
+			return decimal.FromBigRat(mid, scale, decimal.HalfUp)
+		}
+	}
+	return decimal.Decimal{}, ErrNoSolution
+}
+
+// newton finds root of f near x by Newton's method in big.Float, f returns
+// value and derivative. ok is false if not converge.
+func newton(x *big.Float, f func(x *big.Float) (v, dv *big.Float)) (r *big.Float, ok bool) {
+	for i := 0; i < 100; i++ {
+		v, dv := f(x)
+		if v.Sign() == 0 {
+			return x, true
+		}
+		if dv.Sign() == 0 || dv.IsInf() {
+			return x, false
+		}
+
+		step := v.Quo(v, dv)
+		x.Sub(x, step)
+		if x.Sign() <= 0 {
+			return x, false
+		}
+		if step.Sign() == 0 || step.MantExp(nil) < x.MantExp(nil)-prec/2 {
+			return x, true
+		}
+	}
+	return x, false
+}
+
+// powSigned returns x^n, n can be negative.
+func powSigned(x *big.Float, n int) *big.Float {
+	if n >= 0 {
+		return powInt(x, n)
+	}
+	return new(big.Float).SetPrec(prec).Quo(newFloat(1), powInt(x, -n))
+}
+
+// ratPow returns x^n, n can be negative.
+func ratPow(x *big.Rat, n int) *big.Rat {
+	e := big.NewInt(int64(n))
+	if n < 0 {
+		x, e = new(big.Rat).Inv(x), e.Neg(e)
+	}
+	num := new(big.Int).Exp(x.Num(), e, nil)
+	return new(big.Rat).SetFrac(num, new(big.Int).Exp(x.Denom(), e, nil))
+}
+
+func gcd(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func toFloats(values []decimal.Decimal) []float64 {
+	r := make([]float64, len(values))
+	for i, v := range values {
+		r[i] = v.Float64()
+	}
+	return r
+}
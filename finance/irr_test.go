@@ -0,0 +1,69 @@
+package finance_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	. "github.com/redforks/math/finance"
+)
+
+var _ = Describe("IRR", func() {
+	DescribeTable("IRR", func(cashFlows string, exp string) {
+		Ω(IRR(toDecimals(cashFlows), 6)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("numpy example", "-100 39 59 55 20", "0.280948"),
+		Entry("one period", "-100 110", "0.100000"),
+		Entry("negative rate", "-100 50 40", "-0.069926"),
+		Entry("loan", "10000 -3000 -3000 -3000 -3000", "0.077138"),
+		Entry("large rate newton diverges", "-1 0 0 0 0 0 0 0 0 0 1000", "0.995262"),
+	)
+
+	DescribeTable("IRR exact", func(cashFlows string, scale int, exp string) {
+		Ω(IRR(toDecimals(cashFlows), scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("rational root", "-100 10 10 110", 18, "0.100000000000000000"),
+		Entry("rational negative root", "-100 81", 18, "-0.190000000000000000"),
+		Entry("two periods", "-100 0 121", 18, "0.100000000000000000"),
+		Entry("irrational root", "-100 0 200", 18, "0.414213562373095049"),
+		Entry("root at half", "-100 105", 1, "0.1"),
+		Entry("root at negative half", "-100 95", 1, "-0.1"),
+	)
+
+	DescribeTable("IRR no solution", func(cashFlows string) {
+		_, err := IRR(toDecimals(cashFlows), 6)
+		Ω(err).Should(Equal(ErrNoSolution))
+	},
+		Entry("empty", ""),
+		Entry("all positive", "1 2 3"),
+		Entry("all negative", "-1 -2"),
+	)
+
+	It("XIRR", func() {
+		date := func(s string) time.Time {
+			t, err := time.Parse("2006-01-02", s)
+			Ω(err).Should(Succeed())
+			return t
+		}
+
+		// example of Excel XIRR
+		Ω(XIRR(toDecimals("-10000 2750 4250 3250 2750"), []time.Time{
+			date("2008-01-01"), date("2008-03-01"), date("2008-10-30"), date("2009-02-15"), date("2009-04-01"),
+		}, 6)).Should(Equal(toDecimal("0.373363")))
+
+		Ω(XIRR(toDecimals("-100 110"), []time.Time{date("2019-01-01"), date("2020-01-01")}, 4)).Should(Equal(toDecimal("0.1000")))
+
+		Ω(XIRR(toDecimals("-100 121"), []time.Time{date("2021-01-01"), date("2023-01-01")}, 18)).Should(Equal(toDecimal("0.100000000000000000")))
+		Ω(XIRR(toDecimals("-100 110"), []time.Time{date("2019-01-01"), date("2019-07-02")}, 18)).Should(Equal(toDecimal("0.210633821537083935")))
+
+		// whole calendar days, time of day and DST change ignored
+		Ω(XIRR(toDecimals("-100 110"), []time.Time{
+			time.Date(2019, 1, 1, 23, 0, 0, 0, time.FixedZone("EST", -5*3600)),
+			time.Date(2019, 7, 2, 1, 0, 0, 0, time.FixedZone("EDT", -4*3600)),
+		}, 18)).Should(Equal(toDecimal("0.210633821537083935")))
+
+		_, err := XIRR(toDecimals("-100 110"), []time.Time{date("2019-01-01")}, 4)
+		Ω(err).Should(MatchError("[math-finance] 2 cash flows but 1 dates"))
+	})
+})
@@ -0,0 +1,12 @@
+// Package finance contains financial math built on decimal.Decimal, such as
+// time value of money, internal rate of return and loan amortization.
+//
+// Time value of money functions use the same cash flow sign convention as
+// spreadsheets: money received is positive, money paid out is negative, and
+// satisfy:
+//
+//	fv + pv*(1+rate)^nper + pmt*(1+rate*when)*((1+rate)^nper-1)/rate = 0
+//
+// rate is the interest rate per period, such as 0.005 for 6% annual rate paid
+// monthly. Results round half away from zero to specific scale.
+package finance
@@ -0,0 +1,88 @@
+package finance
+
+import (
+	"math/big"
+
+	"github.com/redforks/math/decimal"
+)
+
+// Installment is a row of loan amortization schedule. Principal + Interest
+// equals to Payment exactly.
+type Installment struct {
+	Period    int             // 1 based period number
+	Payment   decimal.Decimal // total payment of the period
+	Principal decimal.Decimal // principal part of the payment
+	Interest  decimal.Decimal // interest part of the payment
+	Balance   decimal.Decimal // remaining principal after the payment
+}
+
+// EqualInstallment returns amortization schedule of loan repaid in equal
+// payments (等额本息). Interest of each period is remaining principal * rate,
+// round to scale. The last payment adjusted to pay off the remaining
+// principal, absorbs rounding differences.
+func EqualInstallment(principal, rate decimal.Decimal, nper int, scale int) ([]Installment, error) {
+	pmt, err := PMT(rate, nper, principal.Neg(), decimal.Zero(0), EndOfPeriod, scale)
+	if err != nil {
+		return nil, err
+	}
+
+	return amortize(principal, rate, nper, scale, func(balance, interest decimal.Decimal) decimal.Decimal {
+		return pmt.Sub(interest)
+	})
+}
+
+// EqualPrincipal returns amortization schedule of loan repaid in equal
+// principal (等额本金), principal / nper round to scale each period, plus
+// interest of remaining principal. Principal part never exceeds remaining
+// principal, periods after paid off are zero. The last payment adjusted to
+// pay off the remaining principal, absorbs rounding differences.
+func EqualPrincipal(principal, rate decimal.Decimal, nper int, scale int) ([]Installment, error) {
+	if err := checkArgs(nper, scale); err != nil {
+		return nil, err
+	}
+
+	part, err := decimal.FromBigRat(new(big.Rat).Quo(principal.ToBigRat(), big.NewRat(int64(nper), 1)), scale, decimal.HalfUp)
+	if err != nil {
+		return nil, err
+	}
+
+	return amortize(principal, rate, nper, scale, func(balance, interest decimal.Decimal) decimal.Decimal {
+		// part rounded up may pay off the balance before the last period
+		if part.Abs().Cmp(balance.Abs()) > 0 {
+			return balance
+		}
+		return part
+	})
+}
+
+// amortize generates schedule, principalOf returns principal part of a
+// period from the balance before the period and interest of the period.
+func amortize(principal, rate decimal.Decimal, nper int, scale int,
+	principalOf func(balance, interest decimal.Decimal) decimal.Decimal) ([]Installment, error) {
+	if err := checkArgs(nper, scale); err != nil {
+		return nil, err
+	}
+
+	balance := principal.Round(scale)
+	r := make([]Installment, nper)
+	for i := range r {
+		interest, err := mulRound(balance, rate, scale)
+		if err != nil {
+			return nil, err
+		}
+
+		p := balance
+		if i != nper-1 {
+			p = principalOf(balance, interest)
+		}
+		balance = balance.Sub(p)
+		r[i] = Installment{
+			Period:    i + 1,
+			Payment:   p.Add(interest),
+			Principal: p,
+			Interest:  interest,
+			Balance:   balance,
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,98 @@
+package finance_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	. "github.com/redforks/math/finance"
+)
+
+var _ = Describe("Amortization schedule", func() {
+	row := func(period int, payment, principal, interest, balance string) Installment {
+		return Installment{period, toDecimal(payment), toDecimal(principal), toDecimal(interest), toDecimal(balance)}
+	}
+
+	// assertSchedule checks every row sums exactly, principals sum to loan.
+	assertSchedule := func(rows []Installment, principal decimal.Decimal) {
+		sum := decimal.Zero(0)
+		for _, r := range rows {
+			Ω(r.Principal.Add(r.Interest)).Should(Equal(r.Payment))
+			sum = sum.Add(r.Principal)
+		}
+		Ω(sum.EQ(principal)).Should(BeTrue())
+		Ω(rows[len(rows)-1].Balance.IsZero()).Should(BeTrue())
+	}
+
+	It("EqualInstallment", func() {
+		rows, err := EqualInstallment(toDecimal("1000"), toDecimal("0.01"), 3, 2)
+		Ω(err).Should(Succeed())
+		Ω(rows).Should(Equal([]Installment{
+			row(1, "340.02", "330.02", "10.00", "669.98"),
+			row(2, "340.02", "333.32", "6.70", "336.66"),
+			row(3, "340.03", "336.66", "3.37", "0.00"),
+		}))
+	})
+
+	It("EqualInstallment mortgage", func() {
+		principal := toDecimal("100000")
+		rows, err := EqualInstallment(principal, toDecimal("0.005"), 360, 2)
+		Ω(err).Should(Succeed())
+		Ω(rows).Should(HaveLen(360))
+		Ω(rows[0]).Should(Equal(row(1, "599.55", "99.55", "500.00", "99900.45")))
+		assertSchedule(rows, principal)
+	})
+
+	It("EqualInstallment zero rate", func() {
+		rows, err := EqualInstallment(toDecimal("100"), toDecimal("0"), 3, 2)
+		Ω(err).Should(Succeed())
+		Ω(rows).Should(Equal([]Installment{
+			row(1, "33.33", "33.33", "0.00", "66.67"),
+			row(2, "33.33", "33.33", "0.00", "33.34"),
+			row(3, "33.34", "33.34", "0.00", "0.00"),
+		}))
+	})
+
+	It("EqualPrincipal", func() {
+		rows, err := EqualPrincipal(toDecimal("1000"), toDecimal("0.01"), 3, 2)
+		Ω(err).Should(Succeed())
+		Ω(rows).Should(Equal([]Installment{
+			row(1, "343.33", "333.33", "10.00", "666.67"),
+			row(2, "340.00", "333.33", "6.67", "333.34"),
+			row(3, "336.67", "333.34", "3.33", "0.00"),
+		}))
+	})
+
+	It("EqualPrincipal paid off before the last period", func() {
+		// 0.05 / 10 rounds up to 0.01
+		rows, err := EqualPrincipal(toDecimal("0.05"), toDecimal("0.01"), 10, 2)
+		Ω(err).Should(Succeed())
+		Ω(rows).Should(Equal([]Installment{
+			row(1, "0.01", "0.01", "0.00", "0.04"),
+			row(2, "0.01", "0.01", "0.00", "0.03"),
+			row(3, "0.01", "0.01", "0.00", "0.02"),
+			row(4, "0.01", "0.01", "0.00", "0.01"),
+			row(5, "0.01", "0.01", "0.00", "0.00"),
+			row(6, "0.00", "0.00", "0.00", "0.00"),
+			row(7, "0.00", "0.00", "0.00", "0.00"),
+			row(8, "0.00", "0.00", "0.00", "0.00"),
+			row(9, "0.00", "0.00", "0.00", "0.00"),
+			row(10, "0.00", "0.00", "0.00", "0.00"),
+		}))
+		assertSchedule(rows, toDecimal("0.05"))
+	})
+
+	It("EqualPrincipal day rate", func() {
+		principal := toDecimal("500000.00")
+		rows, err := EqualPrincipal(principal, toDecimal("0.000123456789"), 365, 2)
+		Ω(err).Should(Succeed())
+		assertSchedule(rows, principal)
+	})
+
+	It("Schedule errors", func() {
+		_, err := EqualInstallment(toDecimal("1000"), toDecimal("0.01"), 0, 2)
+		Ω(err).Should(MatchError("[math-finance] number of periods 0 must greater than 0"))
+
+		_, err = EqualPrincipal(toDecimal("1000"), toDecimal("0.01"), -1, 2)
+		Ω(err).Should(MatchError("[math-finance] number of periods -1 must greater than 0"))
+	})
+})
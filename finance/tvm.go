@@ -0,0 +1,137 @@
+package finance
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/redforks/math/decimal"
+)
+
+const tag = "math-finance"
+
+// PaymentTiming specifies when payments are due in each period.
+type PaymentTiming int
+
+const (
+	// EndOfPeriod payments are due at the end of each period, ordinary annuity.
+	EndOfPeriod PaymentTiming = iota
+
+	// BeginningOfPeriod payments are due at the beginning of each period,
+	// annuity due.
+	BeginningOfPeriod
+)
+
+// PMT returns payment per period of an annuity, such as monthly payment of a
+// loan. Borrow 10000 (pv 10000) returns negative payment. Returns
+// decimal.ErrDivisionByZero if payment undefined, such as rate -1 paid at
+// beginning of period, or (1+rate)^nper is 1 for non-zero rate.
+func PMT(rate decimal.Decimal, nper int, pv, fv decimal.Decimal, when PaymentTiming, scale int) (decimal.Decimal, error) {
+	if err := checkArgs(nper, scale); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	r := rate.ToBigRat()
+	if r.Sign() == 0 {
+		// pmt = -(fv + pv) / n
+		sum := new(big.Rat).Add(fv.ToBigRat(), pv.ToBigRat())
+		return quoRound(sum.Neg(sum), newRat(int64(nper)), scale)
+	}
+
+	// pmt = -(fv + pv*(1+r)^n) * r / ((1+r*when) * ((1+r)^n - 1))
+	f := growth(r, nper)
+	num := new(big.Rat).Mul(pv.ToBigRat(), f)
+	num.Add(num, fv.ToBigRat()).Mul(num, r).Neg(num)
+	den := f.Sub(f, newRat(1))
+	den.Mul(den, timingFactor(r, when))
+	return quoRound(num, den, scale)
+}
+
+// PV returns present value of an annuity, such as loan amount affordable by
+// specific payment. Returns decimal.ErrDivisionByZero if rate is -1.
+func PV(rate decimal.Decimal, nper int, pmt, fv decimal.Decimal, when PaymentTiming, scale int) (decimal.Decimal, error) {
+	if err := checkArgs(nper, scale); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	// pv = -(fv + pmt*annuity) / (1+r)^n
+	r := rate.ToBigRat()
+	num := annuity(r, nper, when)
+	num.Mul(num, pmt.ToBigRat()).Add(num, fv.ToBigRat()).Neg(num)
+	return quoRound(num, growth(r, nper), scale)
+}
+
+// FV returns future value of an annuity, such as balance of a saving account
+// after nper periods.
+func FV(rate decimal.Decimal, nper int, pmt, pv decimal.Decimal, when PaymentTiming, scale int) (decimal.Decimal, error) {
+	if err := checkArgs(nper, scale); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	// fv = -(pv*(1+r)^n + pmt*annuity)
+	r := rate.ToBigRat()
+	fv := annuity(r, nper, when)
+	g := growth(r, nper)
+	fv.Mul(fv, pmt.ToBigRat()).Add(fv, g.Mul(g, pv.ToBigRat()))
+	return fromRat(fv.Neg(fv), scale)
+}
+
+// NPV returns net present value of cash flows at discount rate per period.
+// cashFlows[0] occurs at time 0 and not discounted, cashFlows[i] occurs at the
+// end of period i.
+func NPV(rate decimal.Decimal, cashFlows []decimal.Decimal, scale int) (decimal.Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	r := rate.ToBigRat()
+	r.Add(r, newRat(1))
+	if r.Sign() == 0 {
+		return decimal.Decimal{}, fmt.Errorf("[%s] discount rate must not be -1", tag)
+	}
+
+	// Horner's method, sum from the last cash flow.
+	npv := new(big.Rat)
+	for i := len(cashFlows) - 1; i >= 0; i-- {
+		npv.Quo(npv, r).Add(npv, cashFlows[i].ToBigRat())
+	}
+	return fromRat(npv, scale)
+}
+
+// growth returns (1+r)^n.
+func growth(r *big.Rat, n int) *big.Rat {
+	return powRat(new(big.Rat).Add(r, newRat(1)), n)
+}
+
+// timingFactor returns 1+r*when.
+func timingFactor(r *big.Rat, when PaymentTiming) *big.Rat {
+	if when == BeginningOfPeriod {
+		return new(big.Rat).Add(r, newRat(1))
+	}
+	return newRat(1)
+}
+
+// annuity returns future value factor of payments, (1+r*when)*((1+r)^n-1)/r,
+// n if r is zero.
+func annuity(r *big.Rat, n int, when PaymentTiming) *big.Rat {
+	if r.Sign() == 0 {
+		return newRat(int64(n))
+	}
+
+	f := growth(r, n)
+	f.Sub(f, newRat(1)).Quo(f, r)
+	return f.Mul(f, timingFactor(r, when))
+}
+
+func checkArgs(nper, scale int) error {
+	if nper <= 0 {
+		return fmt.Errorf("[%s] number of periods %d must greater than 0", tag, nper)
+	}
+	return checkScale(scale)
+}
+
+func checkScale(scale int) error {
+	if scale < 0 || scale > decimal.MaxScale {
+		return fmt.Errorf("[%s] scale %d out of range", tag, scale)
+	}
+	return nil
+}
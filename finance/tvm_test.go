@@ -0,0 +1,87 @@
+package finance_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	. "github.com/redforks/math/finance"
+)
+
+var _ = Describe("Time value of money", func() {
+	DescribeTable("PMT", func(rate string, nper int, pv, fv string, when PaymentTiming, exp string) {
+		Ω(PMT(toDecimal(rate), nper, toDecimal(pv), toDecimal(fv), when, 2)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("mortgage", "0.005", 360, "100000", "0", EndOfPeriod, "-599.55"),
+		Entry("beginning of period", "0.005", 360, "100000", "0", BeginningOfPeriod, "-596.57"),
+		Entry("zero rate", "0", 12, "1200", "0", EndOfPeriod, "-100.00"),
+		Entry("zero rate with future value", "0", 10, "0", "-1000", EndOfPeriod, "100.00"),
+		Entry("saving", "0", 3, "0", "100", EndOfPeriod, "-33.33"),
+	)
+
+	DescribeTable("PV", func(rate string, nper int, pmt, fv string, exp string) {
+		Ω(PV(toDecimal(rate), nper, toDecimal(pmt), toDecimal(fv), EndOfPeriod, 2)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("mortgage", "0.005", 360, "-599.55", "0", "99999.91"),
+		Entry("zero rate", "0", 12, "-100", "0", "1200.00"),
+		Entry("discount future value", "0.1", 1, "0", "-110", "100.00"),
+	)
+
+	DescribeTable("FV", func(rate string, nper int, pmt, pv string, when PaymentTiming, exp string) {
+		Ω(FV(toDecimal(rate), nper, toDecimal(pmt), toDecimal(pv), when, 2)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("saving", "0.003333333333333333", 120, "-200", "-1000", EndOfPeriod, "30940.79"),
+		Entry("saving beginning of period", "0.003333333333333333", 120, "-200", "-1000", BeginningOfPeriod, "31038.96"),
+		Entry("zero rate", "0", 10, "-100", "-1000", EndOfPeriod, "2000.00"),
+	)
+
+	It("round exact half away from zero", func() {
+		Ω(PMT(toDecimal("0"), 80, toDecimal("-0.1"), toDecimal("0"), EndOfPeriod, 4)).Should(Equal(toDecimal("0.0013")))
+		Ω(PMT(toDecimal("0.5"), 1, toDecimal("0.01"), toDecimal("0"), EndOfPeriod, 2)).Should(Equal(toDecimal("-0.02")))
+		Ω(PV(toDecimal("1"), 1, toDecimal("0"), toDecimal("-0.01"), EndOfPeriod, 2)).Should(Equal(toDecimal("0.01")))
+		Ω(FV(toDecimal("0.5"), 1, toDecimal("0"), toDecimal("-0.01"), EndOfPeriod, 2)).Should(Equal(toDecimal("0.02")))
+		Ω(NPV(toDecimal("1"), toDecimals("0 0.01"), 2)).Should(Equal(toDecimal("0.01")))
+	})
+
+	It("TVM errors", func() {
+		_, err := PMT(toDecimal("0.01"), 0, toDecimal("100"), toDecimal("0"), EndOfPeriod, 2)
+		Ω(err).Should(MatchError("[math-finance] number of periods 0 must greater than 0"))
+
+		_, err = PV(toDecimal("0.01"), 10, toDecimal("100"), toDecimal("0"), EndOfPeriod, 19)
+		Ω(err).Should(MatchError("[math-finance] scale 19 out of range"))
+
+		_, err = FV(toDecimal("1"), 100, toDecimal("0"), toDecimal("-1"), EndOfPeriod, 2)
+		Ω(err).Should(Equal(decimal.ErrOverflow))
+
+	})
+
+	DescribeTable("TVM division by zero", func(f func() (decimal.Decimal, error)) {
+		_, err := f()
+		Ω(err).Should(Equal(decimal.ErrDivisionByZero))
+	},
+		Entry("PMT rate -1 beginning of period", func() (decimal.Decimal, error) {
+			return PMT(toDecimal("-1"), 10, toDecimal("100"), toDecimal("0"), BeginningOfPeriod, 2)
+		}),
+		Entry("PMT growth is 1", func() (decimal.Decimal, error) {
+			return PMT(toDecimal("-2"), 2, toDecimal("100"), toDecimal("0"), EndOfPeriod, 2)
+		}),
+		Entry("PV rate -1", func() (decimal.Decimal, error) {
+			return PV(toDecimal("-1"), 10, toDecimal("100"), toDecimal("0"), EndOfPeriod, 2)
+		}),
+	)
+
+	DescribeTable("NPV", func(rate, cashFlows string, scale int, exp string) {
+		Ω(NPV(toDecimal(rate), toDecimals(cashFlows), scale)).Should(Equal(toDecimal(exp)))
+	},
+		Entry("empty", "0.1", "", 2, "0.00"),
+		Entry("first not discounted", "0.1", "-100", 2, "-100.00"),
+		Entry("one period", "0.1", "-100 110", 2, "0.00"),
+		Entry("numpy example", "0.281", "-100 39 59 55 20", 4, "-0.0085"),
+		Entry("zero rate", "0", "-100 39 59 55 20", 0, "73"),
+	)
+
+	It("NPV rate -1", func() {
+		_, err := NPV(toDecimal("-1"), toDecimals("1 2"), 2)
+		Ω(err).Should(MatchError("[math-finance] discount rate must not be -1"))
+	})
+})
@@ -0,0 +1,10 @@
+// Package tax computes sales tax such as VAT of invoice lines, tax inclusive
+// or exclusive, simple or compound, rounded per line or per invoice.
+//
+// Tax amounts are computed exactly then round to the scale of the currency.
+// Rounding differences are distributed to lines, so that amounts of lines
+// always sum to invoice totals, and Net + Tax equals to Gross for each line
+// and the invoice.
+package tax
+
+const tag = "math-tax"
@@ -0,0 +1,253 @@
+package tax
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/redforks/math/decimal"
+)
+
+// Rounding specifies where tax amounts are rounded.
+type Rounding int
+
+const (
+	// PerLine round tax of each line, invoice tax is the sum of lines.
+	PerLine Rounding = iota
+
+	// PerInvoice round total tax of each rate on the invoice, the rounding
+	// difference distributed to lines.
+	PerInvoice
+)
+
+// Line is an invoice line item.
+type Line struct {
+	Quantity decimal.Decimal
+	Price    decimal.Decimal   // unit price, includes tax if Calculator.Inclusive
+	Rates    []decimal.Decimal // tax rates as ratio, such as 0.13 for 13%
+}
+
+// LineTax is the result of a Line.
+type LineTax struct {
+	Net   decimal.Decimal   // amount excludes tax
+	Taxes []decimal.Decimal // tax of each rate, in the order of Line.Rates
+	Tax   decimal.Decimal   // sum of Taxes
+	Gross decimal.Decimal   // amount includes tax, Net + Tax
+}
+
+// RateTax is total tax of a rate on the invoice.
+type RateTax struct {
+	Rate decimal.Decimal
+	Tax  decimal.Decimal
+}
+
+// Invoice is the result of lines. Totals are sum of lines.
+type Invoice struct {
+	Lines []LineTax
+	Rates []RateTax // total tax of each rate, in the order of first occurrence
+	Net   decimal.Decimal
+	Tax   decimal.Decimal
+	Gross decimal.Decimal
+}
+
+// Calculator computes tax of invoice lines.
+type Calculator struct {
+	// Inclusive is true if prices include tax, tax is extracted from price,
+	// otherwise tax is added to price.
+	Inclusive bool
+
+	// Compound is true if each tax rate applies to the net amount plus taxes
+	// of previous rates, such as a provincial tax levied on top of a federal
+	// tax. Otherwise all rates apply to the net amount.
+	Compound bool
+
+	// Rounding specifies where tax amounts are rounded.
+	Rounding Rounding
+
+	// Scale of amounts, such as 2 for cents.
+	Scale int
+
+	// Mode of rounding amounts.
+	Mode decimal.RoundingMode
+}
+
+// LineError records a failed computation of a line.
+type LineError struct {
+	Line int   // 1-based line number
+	Err  error // the reason computation failed
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("[%s] line %d: %s", tag, e.Line, e.Err)
+}
+
+// Unwrap returns the reason computation failed.
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// component is tax of a rate of a line.
+type component struct {
+	line, index int
+	exact       *big.Rat
+}
+
+// Calculate computes tax of lines. Returns error if scale out of range, an
+// amount out of range, or tax can not be extracted from an inclusive price,
+// such as rates sum to -100%. Errors of a line are *LineError.
+func (c Calculator) Calculate(lines []Line) (Invoice, error) {
+	if c.Scale < 0 || c.Scale > decimal.MaxScale {
+		return Invoice{}, fmt.Errorf("[%s] scale %d out of range", tag, c.Scale)
+	}
+
+	inv := Invoice{Lines: make([]LineTax, len(lines))}
+	var groups [][]component
+	rateIndex := map[string]int{}
+	for i, l := range lines {
+		amount, taxes, err := c.exact(l)
+		if err != nil {
+			return Invoice{}, &LineError{i + 1, err}
+		}
+
+		lt := &inv.Lines[i]
+		lt.Taxes = make([]decimal.Decimal, len(taxes))
+		if lt.Net, err = decimal.FromBigRat(amount, c.Scale, c.Mode); err != nil {
+			return Invoice{}, &LineError{i + 1, err}
+		}
+		for j, t := range taxes {
+			key := l.Rates[j].ToBigRat().RatString()
+			g, ok := rateIndex[key]
+			if !ok {
+				g = len(groups)
+				rateIndex[key] = g
+				groups = append(groups, nil)
+				inv.Rates = append(inv.Rates, RateTax{Rate: l.Rates[j]})
+			}
+			groups[g] = append(groups[g], component{i, j, t})
+		}
+	}
+
+	for g, comps := range groups {
+		if err := c.roundGroup(inv.Lines, comps); err != nil {
+			return Invoice{}, err
+		}
+
+		sum := decimal.Zero(c.Scale)
+		for _, comp := range comps {
+			sum = sum.Add(inv.Lines[comp.line].Taxes[comp.index])
+		}
+		inv.Rates[g].Tax = sum
+	}
+
+	inv.Net, inv.Tax, inv.Gross = decimal.Zero(c.Scale), decimal.Zero(c.Scale), decimal.Zero(c.Scale)
+	for i := range inv.Lines {
+		lt := &inv.Lines[i]
+		lt.Tax = decimal.Zero(c.Scale)
+		for _, t := range lt.Taxes {
+			lt.Tax = lt.Tax.Add(t)
+		}
+		// Net holds the rounded price amount so far.
+		if c.Inclusive {
+			lt.Gross = lt.Net
+			lt.Net = lt.Gross.Sub(lt.Tax)
+		} else {
+			lt.Gross = lt.Net.Add(lt.Tax)
+		}
+
+		inv.Net = inv.Net.Add(lt.Net)
+		inv.Tax = inv.Tax.Add(lt.Tax)
+		inv.Gross = inv.Gross.Add(lt.Gross)
+	}
+	return inv, nil
+}
+
+// exact returns exact price amount and taxes of the line.
+func (c Calculator) exact(l Line) (amount *big.Rat, taxes []*big.Rat, err error) {
+	amount = new(big.Rat).Mul(l.Quantity.ToBigRat(), l.Price.ToBigRat())
+	rates := make([]*big.Rat, len(l.Rates))
+	for i, r := range l.Rates {
+		rates[i] = r.ToBigRat()
+	}
+
+	net := amount
+	if c.Inclusive {
+		// gross = net * factor
+		factor := big.NewRat(1, 1)
+		for _, r := range rates {
+			if c.Compound {
+				factor.Mul(factor, new(big.Rat).Add(r, big.NewRat(1, 1)))
+			} else {
+				factor.Add(factor, r)
+			}
+		}
+		if factor.Sign() == 0 {
+			return nil, nil, fmt.Errorf("can not extract tax of rates %s from price", l.Rates)
+		}
+		net = new(big.Rat).Quo(amount, factor)
+	}
+
+	base := new(big.Rat).Set(net)
+	taxes = make([]*big.Rat, len(rates))
+	for i, r := range rates {
+		if c.Compound {
+			taxes[i] = new(big.Rat).Mul(base, r)
+			base.Add(base, taxes[i])
+		} else {
+			taxes[i] = new(big.Rat).Mul(net, r)
+		}
+	}
+	return amount, taxes, nil
+}
+
+// roundGroup round tax components of a rate and save to lines. If round per
+// invoice, the total of components is rounded, and the difference to the sum
+// of rounded components is distributed one unit each to components having the
+// largest rounding residual.
+func (c Calculator) roundGroup(lines []LineTax, comps []component) error {
+	total, sum := new(big.Rat), new(big.Rat)
+	residuals := make([]*big.Rat, len(comps))
+	for i, comp := range comps {
+		t, err := decimal.FromBigRat(comp.exact, c.Scale, c.Mode)
+		if err != nil {
+			return &LineError{comp.line + 1, err}
+		}
+		lines[comp.line].Taxes[comp.index] = t
+
+		total.Add(total, comp.exact)
+		sum.Add(sum, t.ToBigRat())
+		residuals[i] = new(big.Rat).Sub(comp.exact, t.ToBigRat())
+	}
+	if c.Rounding == PerLine {
+		return nil
+	}
+
+	t, err := decimal.FromBigRat(total, c.Scale, c.Mode)
+	if err != nil {
+		return err
+	}
+	// scale already validated by FromBigRat above
+	step := decimal.New(1, c.Scale)
+	diff := new(big.Rat).Sub(t.ToBigRat(), sum)
+	n := diff.Quo(diff, step.ToBigRat()).Num().Int64()
+	if n == 0 {
+		return nil
+	}
+
+	order := make([]int, len(comps))
+	for i := range order {
+		order[i] = i
+	}
+	sign := 1
+	if n < 0 {
+		step, n, sign = step.Neg(), -n, -1
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return residuals[order[i]].Cmp(residuals[order[j]]) == sign
+	})
+
+	for i := int64(0); i < n; i++ {
+		comp := comps[order[i%int64(len(order))]]
+		lines[comp.line].Taxes[comp.index] = lines[comp.line].Taxes[comp.index].Add(step)
+	}
+	return nil
+}
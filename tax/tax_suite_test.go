@@ -0,0 +1,32 @@
+package tax_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	"github.com/redforks/testing/matcher"
+
+	"testing"
+)
+
+func TestTax(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tax Suite")
+}
+
+// toDecimal parse decimal string, fails the test if s not a number.
+func toDecimal(s string) (d decimal.Decimal) {
+	Ω(decimal.FromString(s)).Should(matcher.Save(&d))
+	return
+}
+
+// toDecimals parse space separated decimal strings.
+func toDecimals(s string) []decimal.Decimal {
+	r := []decimal.Decimal{}
+	for _, f := range strings.Fields(s) {
+		r = append(r, toDecimal(f))
+	}
+	return r
+}
@@ -0,0 +1,210 @@
+package tax_test
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redforks/math/decimal"
+	. "github.com/redforks/math/tax"
+)
+
+var _ = Describe("Calculator", func() {
+	line := func(quantity, price, rates string) Line {
+		return Line{toDecimal(quantity), toDecimal(price), toDecimals(rates)}
+	}
+
+	lineTax := func(net, taxes, tax, gross string) LineTax {
+		return LineTax{toDecimal(net), toDecimals(taxes), toDecimal(tax), toDecimal(gross)}
+	}
+
+	// assertTotals checks invoice totals are sum of lines.
+	assertTotals := func(inv Invoice) {
+		net, tax, gross := decimal.Zero(0), decimal.Zero(0), decimal.Zero(0)
+		for _, l := range inv.Lines {
+			Ω(l.Net.Add(l.Tax).EQ(l.Gross)).Should(BeTrue())
+			net, tax, gross = net.Add(l.Net), tax.Add(l.Tax), gross.Add(l.Gross)
+		}
+		Ω(net.EQ(inv.Net)).Should(BeTrue())
+		Ω(tax.EQ(inv.Tax)).Should(BeTrue())
+		Ω(gross.EQ(inv.Gross)).Should(BeTrue())
+		Ω(inv.Net.Add(inv.Tax).EQ(inv.Gross)).Should(BeTrue())
+
+		rateSum := decimal.Zero(0)
+		for _, r := range inv.Rates {
+			rateSum = rateSum.Add(r.Tax)
+		}
+		Ω(rateSum.EQ(inv.Tax)).Should(BeTrue())
+	}
+
+	It("exclusive per line", func() {
+		inv, err := Calculator{Scale: 2}.Calculate([]Line{
+			line("1", "10.00", "0.13"),
+			line("3", "0.35", "0.13"),
+			line("2", "5", ""),
+		})
+		Ω(err).Should(Succeed())
+		Ω(inv.Lines).Should(Equal([]LineTax{
+			lineTax("10.00", "1.30", "1.30", "11.30"),
+			lineTax("1.05", "0.14", "0.14", "1.19"),
+			lineTax("10.00", "", "0.00", "10.00"),
+		}))
+		Ω(inv.Rates).Should(Equal([]RateTax{{toDecimal("0.13"), toDecimal("1.44")}}))
+		Ω(inv.Net).Should(Equal(toDecimal("21.05")))
+		Ω(inv.Tax).Should(Equal(toDecimal("1.44")))
+		Ω(inv.Gross).Should(Equal(toDecimal("22.49")))
+		assertTotals(inv)
+	})
+
+	It("per invoice rounding", func() {
+		lines := []Line{
+			line("1", "0.05", "0.1"),
+			line("1", "0.05", "0.1"),
+			line("1", "0.05", "0.1"),
+		}
+
+		inv, err := Calculator{Scale: 2}.Calculate(lines)
+		Ω(err).Should(Succeed())
+		Ω(inv.Tax).Should(Equal(toDecimal("0.03")))
+
+		inv, err = Calculator{Scale: 2, Rounding: PerInvoice}.Calculate(lines)
+		Ω(err).Should(Succeed())
+		Ω(inv.Lines).Should(Equal([]LineTax{
+			lineTax("0.05", "0.00", "0.00", "0.05"),
+			lineTax("0.05", "0.01", "0.01", "0.06"),
+			lineTax("0.05", "0.01", "0.01", "0.06"),
+		}))
+		Ω(inv.Tax).Should(Equal(toDecimal("0.02")))
+		assertTotals(inv)
+	})
+
+	It("per invoice rounding up", func() {
+		inv, err := Calculator{Scale: 2, Rounding: PerInvoice, Mode: decimal.HalfEven}.Calculate([]Line{
+			line("1", "0.14", "0.1"),
+			line("1", "0.13", "0.1"),
+			line("1", "0.14", "0.1"),
+		})
+		Ω(err).Should(Succeed())
+		// 0.014 + 0.013 + 0.014 = 0.041, round to 0.04, lines round to 0.01
+		// each, the largest residual gets the difference.
+		Ω(inv.Lines[0].Tax).Should(Equal(toDecimal("0.02")))
+		Ω(inv.Lines[1].Tax).Should(Equal(toDecimal("0.01")))
+		Ω(inv.Lines[2].Tax).Should(Equal(toDecimal("0.01")))
+		Ω(inv.Tax).Should(Equal(toDecimal("0.04")))
+		assertTotals(inv)
+	})
+
+	It("inclusive", func() {
+		inv, err := Calculator{Inclusive: true, Scale: 2}.Calculate([]Line{
+			line("1", "113", "0.13"),
+			line("1", "10.00", "0.13"),
+		})
+		Ω(err).Should(Succeed())
+		Ω(inv.Lines).Should(Equal([]LineTax{
+			lineTax("100.00", "13.00", "13.00", "113.00"),
+			lineTax("8.85", "1.15", "1.15", "10.00"),
+		}))
+		assertTotals(inv)
+	})
+
+	It("multiple rates", func() {
+		lines := []Line{line("1", "100", "0.05 0.095")}
+
+		inv, err := Calculator{Scale: 2}.Calculate(lines)
+		Ω(err).Should(Succeed())
+		Ω(inv.Lines[0]).Should(Equal(lineTax("100.00", "5.00 9.50", "14.50", "114.50")))
+
+		inv, err = Calculator{Scale: 2, Compound: true}.Calculate(lines)
+		Ω(err).Should(Succeed())
+		Ω(inv.Lines[0]).Should(Equal(lineTax("100.00", "5.00 9.98", "14.98", "114.98")))
+		Ω(inv.Rates).Should(Equal([]RateTax{
+			{toDecimal("0.05"), toDecimal("5.00")},
+			{toDecimal("0.095"), toDecimal("9.98")},
+		}))
+
+		inv, err = Calculator{Scale: 2, Compound: true, Inclusive: true}.Calculate([]Line{
+			line("1", "114.975", "0.05 0.095"),
+		})
+		Ω(err).Should(Succeed())
+		Ω(inv.Lines[0]).Should(Equal(lineTax("100.00", "5.00 9.98", "14.98", "114.98")))
+	})
+
+	It("rates of different scale are the same rate", func() {
+		inv, err := Calculator{Scale: 2}.Calculate([]Line{
+			line("1", "10", "0.13"),
+			line("1", "10", "0.130 0.05"),
+		})
+		Ω(err).Should(Succeed())
+		Ω(inv.Rates).Should(Equal([]RateTax{
+			{toDecimal("0.13"), toDecimal("2.60")},
+			{toDecimal("0.05"), toDecimal("0.50")},
+		}))
+	})
+
+	It("reconciles", func() {
+		rates := toDecimals("0.06 0.09 0.13 0.17")
+		for i := 0; i < 50; i++ {
+			c := Calculator{
+				Inclusive: rand.Intn(2) == 0,
+				Compound:  rand.Intn(2) == 0,
+				Rounding:  Rounding(rand.Intn(2)),
+				Scale:     rand.Intn(3),
+				Mode:      decimal.RoundingMode(rand.Intn(7)),
+			}
+			lines := make([]Line, rand.Intn(10)+1)
+			for j := range lines {
+				lines[j] = Line{
+					Quantity: decimal.FromInt(rand.Int63n(5) + 1),
+					Price:    decimal.FromInt(rand.Int63n(100000)).DivToScale(decimal.FromInt(1000), 3),
+					Rates:    rates[:rand.Intn(len(rates)+1)],
+				}
+			}
+
+			inv, err := c.Calculate(lines)
+			Ω(err).Should(Succeed())
+			assertTotals(inv)
+
+			if c.Rounding == PerInvoice && !c.Inclusive && !c.Compound {
+				for _, r := range inv.Rates {
+					exact := new(big.Rat)
+					for _, l := range lines {
+						for _, lr := range l.Rates {
+							if lr.EQ(r.Rate) {
+								v := new(big.Rat).Mul(ratOf(l.Quantity), ratOf(l.Price))
+								exact.Add(exact, v.Mul(v, ratOf(lr)))
+							}
+						}
+					}
+					diff := new(big.Rat).Sub(exact, ratOf(r.Tax))
+					Ω(diff.Abs(diff).Cmp(big.NewRat(1, 1))).Should(BeNumerically("<", 0), "%v", c)
+				}
+			}
+		}
+	})
+
+	It("errors", func() {
+		_, err := Calculator{Scale: 19}.Calculate(nil)
+		Ω(err).Should(MatchError("[math-tax] scale 19 out of range"))
+
+		_, err = Calculator{Inclusive: true}.Calculate([]Line{line("1", "1", "0.5 -1.5")})
+		Ω(err).Should(MatchError("[math-tax] line 1: can not extract tax of rates [0.5 -1.5] from price"))
+
+		_, err = Calculator{}.Calculate([]Line{line("1", "1", ""), line("9223372036854775807", "2", "")})
+		Ω(err).Should(MatchError("[math-tax] line 2: [decimal] value out of range"))
+		Ω(errors.Is(err, decimal.ErrOverflow)).Should(BeTrue())
+
+		_, err = Calculator{}.Calculate([]Line{line("9223372036854775807", "1", "2")})
+		Ω(err).Should(MatchError("[math-tax] line 1: [decimal] value out of range"))
+		var le *LineError
+		Ω(errors.As(err, &le)).Should(BeTrue())
+		Ω(le.Line).Should(Equal(1))
+		Ω(errors.Is(err, decimal.ErrOverflow)).Should(BeTrue())
+	})
+})
+
+func ratOf(d decimal.Decimal) *big.Rat {
+	r, _ := new(big.Rat).SetString(d.String())
+	return r
+}